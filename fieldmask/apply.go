@@ -0,0 +1,167 @@
+package fieldmask
+
+import "strings"
+
+// KeyMatcher reports whether the mask path segment selects the map key
+type KeyMatcher func(segment, mapKey string) bool
+
+// ApplyOptions specifies properties for the Apply function
+type ApplyOptions struct {
+	// KeyMatcher matches the mask segments with the map keys, by default
+	// a segment selects the key equal to it
+	KeyMatcher KeyMatcher
+	// VirtualFields are the paths of fields computed after the mask is applied e.g. `fullName` or
+	// `author/fullName`. When the mask selects a virtual field by name which is missing from the data
+	// its key is kept with a nil value, as a placeholder for the post-processor filling it
+	VirtualFields []string
+}
+
+// Apply returns a copy of the data, as decoded from JSON, with only the fields selected by the mask.
+// Arrays are projected element by element, a `*` segment selects every key of an object and
+// a `**` segment any number of nested keys e.g. `items/**/id` selects the ids at any depth
+func Apply(mask string, data interface{}, opts ...ApplyOptions) (interface{}, error) {
+	paths, err := Masks(mask)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range paths {
+		paths[i] = collapse(p)
+	}
+	matcher := func(segment, key string) bool {
+		return segment == key
+	}
+	var virtual [][]string
+	for _, opt := range opts {
+		if opt.KeyMatcher != nil {
+			matcher = opt.KeyMatcher
+		}
+		for _, field := range opt.VirtualFields {
+			virtual = append(virtual, strings.Split(field, "/"))
+		}
+	}
+	value, _ := project(data, paths, matcher, virtual...)
+	return value, nil
+}
+
+// project returns the value with only the paths selected, it returns false when none of the paths exist.
+// The selected virtual fields missing from the data are added with a nil value
+func project(data interface{}, paths [][]string, matcher KeyMatcher, virtual ...[]string) (interface{}, bool) {
+	for _, p := range paths {
+		if len(p) == 0 || len(p) == 1 && p[0] == "**" {
+			return data, true
+		}
+	}
+	switch v := data.(type) {
+	case map[string]interface{}:
+		result := map[string]interface{}{}
+		for key, value := range v {
+			var rest [][]string
+			for _, p := range paths {
+				if p[0] == "**" {
+					// the key is either matched by the segment following ** or is one of the nested keys
+					if p[1] == "*" || matcher(p[1], key) {
+						rest = append(rest, p[2:])
+					}
+					rest = append(rest, p)
+				} else if p[0] == "*" || matcher(p[0], key) {
+					rest = append(rest, p[1:])
+				}
+			}
+			if len(rest) == 0 {
+				continue
+			}
+			var nested [][]string
+			for _, f := range virtual {
+				if len(f) > 1 && matcher(f[0], key) {
+					nested = append(nested, f[1:])
+				}
+			}
+			if value, ok := project(value, rest, matcher, nested...); ok {
+				result[key] = value
+			}
+		}
+		for _, f := range virtual {
+			if _, ok := result[f[0]]; ok || len(f) != 1 || !selected(paths, f[0], matcher) {
+				continue
+			}
+			missing := true
+			for key := range v {
+				if matcher(f[0], key) {
+					missing = false
+					break
+				}
+			}
+			if missing {
+				result[f[0]] = nil
+			}
+		}
+		return result, len(result) > 0
+	case []interface{}:
+		result := []interface{}{}
+		for _, value := range v {
+			if value, ok := project(value, paths, matcher, virtual...); ok {
+				result = append(result, value)
+			}
+		}
+		return result, len(result) > 0
+	}
+	return nil, false
+}
+
+// collapse returns the path with the consecutive `**` segments merged into one,
+// `a/**/**/id` selects the same fields as `a/**/id`
+func collapse(path []string) []string {
+	var collapsed []string
+	for i, segment := range path {
+		if segment == "**" && i > 0 && path[i-1] == "**" {
+			continue
+		}
+		collapsed = append(collapsed, segment)
+	}
+	return collapsed
+}
+
+// selected reports whether one of the paths selects the whole field by name
+func selected(paths [][]string, field string, matcher KeyMatcher) bool {
+	for _, p := range paths {
+		if len(p) == 1 && p[0] != "*" && p[0] != "**" && matcher(p[0], field) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether the path is selected by the mask, either because a mask path
+// matches it or one of its parents e.g. `items(id,author)` contains `items/author/uri`
+func Contains(mask string, path ...string) (bool, error) {
+	paths, err := Masks(mask)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range paths {
+		if contains(p, path) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// contains reports whether the mask path selects the path
+func contains(mask, path []string) bool {
+	switch {
+	case len(mask) == 0:
+		return true
+	case mask[0] == "**":
+		for i := range path {
+			if contains(mask[1:], path[i:]) {
+				return true
+			}
+		}
+		return len(mask) == 1
+	case len(path) == 0:
+		return false
+	case mask[0] == "*" || mask[0] == path[0]:
+		return contains(mask[1:], path[1:])
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package fieldmask
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const applyData = `{
+	"etag": "abc",
+	"items": [
+		{"id": 1, "title": "go", "author": {"name": "peter", "uri": "/peter"}},
+		{"id": 2, "title": "rust", "author": {"name": "mary", "uri": "/mary"}}
+	],
+	"context": {"facets": [{"label": "a", "anchor": "b"}]}
+}`
+
+func decode(t *testing.T, data string) interface{} {
+	var v interface{}
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("Expected to decode %s without error, got: %v", data, err)
+	}
+	return v
+}
+
+func TestApply(t *testing.T) {
+	cases := map[string]string{
+		"etag":                    `{"etag": "abc"}`,
+		"etag,items/id":           `{"etag": "abc", "items": [{"id": 1}, {"id": 2}]}`,
+		"items(title,author/uri)": `{"items": [{"title": "go", "author": {"uri": "/peter"}}, {"title": "rust", "author": {"uri": "/mary"}}]}`,
+		"items/author/*":          `{"items": [{"author": {"name": "peter", "uri": "/peter"}}, {"author": {"name": "mary", "uri": "/mary"}}]}`,
+		"context/facets/label":    `{"context": {"facets": [{"label": "a"}]}}`,
+		"missing,etag/value":      `{}`,
+	}
+	for mask, expected := range cases {
+		got, err := Apply(mask, decode(t, applyData))
+		assert.NoError(t, err, mask)
+		assert.Equal(t, decode(t, expected), got, mask)
+	}
+
+	_, err := Apply("items(id", decode(t, applyData))
+	assert.Error(t, err)
+}
+
+func TestApplyKeyMatcher(t *testing.T) {
+	opt := ApplyOptions{KeyMatcher: strings.EqualFold}
+
+	got, err := Apply("ETag,Items/ID", decode(t, applyData), opt)
+	assert.NoError(t, err)
+	assert.Equal(t, decode(t, `{"etag": "abc", "items": [{"id": 1}, {"id": 2}]}`), got)
+
+	got, err = Apply("ETag,Items/ID", decode(t, applyData))
+	assert.NoError(t, err)
+	assert.Equal(t, decode(t, `{}`), got)
+}
+
+const nestedData = `{
+	"items": [
+		{"id": 1, "author": {"id": 10, "name": "peter"}, "tags": [{"id": 100, "label": "go"}]},
+		{"id": 2, "meta": {"owner": {"id": 20}}}
+	]
+}`
+
+func TestApplyVirtualFields(t *testing.T) {
+	opt := ApplyOptions{VirtualFields: []string{"fullName", "items/author/fullName", "items/score"}}
+	cases := map[string]string{
+		"etag,fullName":               `{"etag": "abc", "fullName": null}`,
+		"fullName":                    `{"fullName": null}`,
+		"etag":                        `{"etag": "abc"}`,
+		"items(id,score)":             `{"items": [{"id": 1, "score": null}, {"id": 2, "score": null}]}`,
+		"items/author(name,fullName)": `{"items": [{"author": {"name": "peter", "fullName": null}}, {"author": {"name": "mary", "fullName": null}}]}`,
+		"items/author/*":              `{"items": [{"author": {"name": "peter", "uri": "/peter"}}, {"author": {"name": "mary", "uri": "/mary"}}]}`,
+		"items/title":                 `{"items": [{"title": "go"}, {"title": "rust"}]}`,
+		"context/fullName":            `{}`,
+	}
+	for mask, expected := range cases {
+		got, err := Apply(mask, decode(t, applyData), opt)
+		assert.NoError(t, err, mask)
+		assert.Equal(t, decode(t, expected), got, mask)
+	}
+
+	// fields present in the data are projected as usual
+	got, err := Apply("etag,fullName", decode(t, `{"etag": "abc", "fullName": "Peter Pan"}`), opt)
+	assert.NoError(t, err)
+	assert.Equal(t, decode(t, `{"etag": "abc", "fullName": "Peter Pan"}`), got)
+
+	got, err = Apply("ETag,FullName", decode(t, applyData), ApplyOptions{
+		KeyMatcher:    strings.EqualFold,
+		VirtualFields: []string{"fullName"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, decode(t, `{"etag": "abc", "fullName": null}`), got)
+
+	got, err = Apply("etag,fullName", decode(t, applyData))
+	assert.NoError(t, err)
+	assert.Equal(t, decode(t, `{"etag": "abc"}`), got)
+}
+
+func TestApplyRecursiveWildcard(t *testing.T) {
+	cases := map[string]string{
+		"items/*/id":     `{"items": [{"author": {"id": 10}, "tags": [{"id": 100}]}]}`,
+		"items/**/id":    `{"items": [{"id": 1, "author": {"id": 10}, "tags": [{"id": 100}]}, {"id": 2, "meta": {"owner": {"id": 20}}}]}`,
+		"items/**":       nestedData,
+		"**/name":        `{"items": [{"author": {"name": "peter"}}]}`,
+		"**/label":       `{"items": [{"tags": [{"label": "go"}]}]}`,
+		"**/**":          nestedData,
+		"items/**/**/id": `{"items": [{"id": 1, "author": {"id": 10}, "tags": [{"id": 100}]}, {"id": 2, "meta": {"owner": {"id": 20}}}]}`,
+	}
+	for mask, expected := range cases {
+		got, err := Apply(mask, decode(t, nestedData))
+		assert.NoError(t, err, mask)
+		assert.Equal(t, decode(t, expected), got, mask)
+	}
+
+	// arrays not reached by the mask are left out
+	data := `{"a": {"id": 1, "name": "x"}, "b": [1, 2]}`
+	cases = map[string]string{
+		"**/id":      `{"a": {"id": 1}}`,
+		"b/x":        `{}`,
+		"a/id,b":     `{"a": {"id": 1}, "b": [1, 2]}`,
+		"**/**":      data,
+		"a/**/**/id": `{"a": {"id": 1}}`,
+	}
+	for mask, expected := range cases {
+		got, err := Apply(mask, decode(t, data))
+		assert.NoError(t, err, mask)
+		assert.Equal(t, decode(t, expected), got, mask)
+	}
+}
+
+func TestContains(t *testing.T) {
+	cases := []struct {
+		mask     string
+		path     []string
+		expected bool
+	}{
+		{"items(id,author)", []string{"items", "id"}, true},
+		{"items(id,author)", []string{"items", "author", "uri"}, true},
+		{"items(id,author)", []string{"items"}, false},
+		{"items(id,author)", []string{"etag"}, false},
+		{"items/*/id", []string{"items", "author", "id"}, true},
+		{"items/*/id", []string{"items", "meta", "owner", "id"}, false},
+		{"items/**/id", []string{"items", "id"}, true},
+		{"items/**/id", []string{"items", "author", "id"}, true},
+		{"items/**/id", []string{"items", "meta", "owner", "id"}, true},
+		{"items/**/id", []string{"items", "meta", "owner", "name"}, false},
+		{"items/**", []string{"items", "meta", "owner"}, true},
+		{"items/**", []string{"etag"}, false},
+	}
+	for _, tc := range cases {
+		got, err := Contains(tc.mask, tc.path...)
+		assert.NoError(t, err, tc.mask)
+		assert.Equal(t, tc.expected, got, "%s contains %v", tc.mask, tc.path)
+	}
+
+	_, err := Contains("items(id", "items")
+	assert.Error(t, err)
+}
@@ -0,0 +1,49 @@
+package fieldmask
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrFieldNotAllowed is returned by ToColumns for fields which are not allowed
+var ErrFieldNotAllowed = errors.New("field not allowed")
+
+// ToColumns returns the SQL select columns of the mask over a flat table. Nested paths are flattened
+// by joining their segments with `_` e.g. `author/name` selects the column author_name, a `*` selects
+// all the allowed columns. An error wrapping ErrFieldNotAllowed is returned for the columns not allowed
+func ToColumns(mask string, allowed []string) ([]string, error) {
+	paths, err := Masks(mask)
+	if err != nil {
+		return nil, err
+	}
+	var columns []string
+	seen := map[string]bool{}
+	add := func(column string) {
+		if !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+	for _, p := range paths {
+		column := strings.Join(p, "_")
+		if column == "*" {
+			for _, c := range allowed {
+				add(c)
+			}
+			continue
+		}
+		found := false
+		for _, c := range allowed {
+			if c == column {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: `%s`", ErrFieldNotAllowed, strings.Join(p, "/"))
+		}
+		add(column)
+	}
+	return columns, nil
+}
@@ -0,0 +1,34 @@
+package fieldmask
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToColumns(t *testing.T) {
+	allowed := []string{"id", "title", "author_name", "created_at"}
+	cases := map[string][]string{
+		"id,title":                {"id", "title"},
+		"title , id, title":       {"title", "id"},
+		"author(name),created_at": {"author_name", "created_at"},
+		"author/name":             {"author_name"},
+		"*":                       {"id", "title", "author_name", "created_at"},
+		"title,*":                 {"title", "id", "author_name", "created_at"},
+	}
+	for mask, expected := range cases {
+		got, err := ToColumns(mask, allowed)
+		assert.NoError(t, err, mask)
+		assert.Equal(t, expected, got, mask)
+	}
+
+	for _, mask := range []string{"password", "id,author/email", "author"} {
+		got, err := ToColumns(mask, allowed)
+		assert.True(t, errors.Is(err, ErrFieldNotAllowed), "%s: %v", mask, err)
+		assert.Empty(t, got, mask)
+	}
+
+	_, err := ToColumns("id(", allowed)
+	assert.Error(t, err)
+}
@@ -18,13 +18,136 @@ import (
 	"unicode/utf8"
 )
 
+var (
+	// ErrMaxDepthExceeded is returned when a mask path has more segments than allowed
+	ErrMaxDepthExceeded = errors.New("maximum mask depth exceeded")
+	// ErrMaxPathsExceeded is returned when a mask selects more paths than allowed
+	ErrMaxPathsExceeded = errors.New("maximum number of mask paths exceeded")
+)
+
+// MasksOptions specifies properties for the Masks function
+type MasksOptions struct {
+	// MaxDepth is the maximum number of segments a single path may have, no limit when 0
+	MaxDepth int
+	// MaxPaths is the maximum number of paths the mask may select, no limit when 0
+	MaxPaths int
+	// DotIsSeparator splits unquoted segments on `.` in addition to `/` so `a.b.c` selects the path
+	// a/b/c. By default `.` is part of the field name and `a.b.c` selects the single field "a.b.c"
+	DotIsSeparator bool
+	// TrailingComma tolerates a trailing comma after the last field of the mask or of a
+	// sub-selector e.g. `items(id,title,)`, which is rejected by default
+	TrailingComma bool
+}
+
 // Masks extracts the field masks from the given query
-func Masks(q string) ([][]string, error) {
-	got, err := Parse("TestMaskQueries", []byte(q))
+func Masks(q string, opts ...MasksOptions) ([][]string, error) {
+	// memoizing keeps the parse linear in the nesting of the sub-selectors
+	parseOpts := []Option{Memoize(true)}
+	for _, opt := range opts {
+		parseOpts = append(parseOpts, GlobalStore("dotIsSeparator", opt.DotIsSeparator))
+		parseOpts = append(parseOpts, GlobalStore("trailingComma", opt.TrailingComma))
+		// every sub-selector adds a segment to its paths, so deep masks are rejected before parsing
+		if depth := nesting(q); opt.MaxDepth > 0 && depth >= opt.MaxDepth {
+			return [][]string{}, fmt.Errorf("%w: sub-selectors nested %d deep, limit is %d segments", ErrMaxDepthExceeded, depth, opt.MaxDepth)
+		}
+	}
+	got, err := Parse("TestMaskQueries", []byte(q), parseOpts...)
 	if err != nil {
 		return [][]string{}, err
 	}
-	return got.([][]string), err
+	paths := got.([][]string)
+	for _, opt := range opts {
+		if opt.MaxPaths > 0 && len(paths) > opt.MaxPaths {
+			return [][]string{}, fmt.Errorf("%w: %d paths selected, limit is %d", ErrMaxPathsExceeded, len(paths), opt.MaxPaths)
+		}
+		for _, p := range paths {
+			if opt.MaxDepth > 0 && len(p) > opt.MaxDepth {
+				return [][]string{}, fmt.Errorf("%w: `%s` has %d segments, limit is %d", ErrMaxDepthExceeded, strings.Join(p, "/"), len(p), opt.MaxDepth)
+			}
+		}
+	}
+	return paths, err
+}
+
+// nesting returns the deepest nesting of the sub-selectors of the mask, ignoring the parentheses of quoted segments
+func nesting(q string) int {
+	depth, max, quoted, escaped := 0, 0, false, false
+	for _, r := range q {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+			if depth > max {
+				max = depth
+			}
+		case r == ')':
+			depth--
+		}
+	}
+	return max
+}
+
+// Compact returns the canonical whitespace free form of the mask, which lists every selected path
+// separated by `,` e.g. `items ( id, author/uri )` becomes `items/id,items/author/uri`
+func Compact(mask string) (string, error) {
+	// the quoted `*` and `**` segments are kept apart from the wildcards to be quoted again
+	got, err := Parse("Compact", []byte(mask), Memoize(true), GlobalStore("literalStars", true))
+	if err != nil {
+		return "", err
+	}
+	return compact(got.([][]string)), nil
+}
+
+// literalStar marks a quoted `*` or `**` segment in the paths parsed by Compact
+const literalStar = "\x00"
+
+// compact returns the paths in the compact form, segments with mask syntax characters are quoted
+func compact(paths [][]string) string {
+	var values []string
+	for _, p := range paths {
+		names := make([]string, len(p))
+		for i, name := range p {
+			if strings.HasPrefix(name, literalStar) {
+				name = strconv.Quote(strings.TrimPrefix(name, literalStar))
+			} else if name == "" || strings.ContainsAny(name, ": \t\r\n)(/,\"\\") {
+				name = strconv.Quote(name)
+			}
+			names[i] = name
+		}
+		values = append(values, strings.Join(names, "/"))
+	}
+	return strings.Join(values, ",")
+}
+
+// quotedTerm is a quoted path segment which is always used as is
+type quotedTerm string
+
+// segments returns the path segments of the term, spaces are removed from unquoted terms
+// which are also split on `.` when enabled
+func segments(c *current, v interface{}) []string {
+	if q, ok := v.(quotedTerm); ok {
+		if literal, _ := c.globalStore["literalStars"].(bool); literal && (q == "*" || q == "**") {
+			return []string{literalStar + string(q)}
+		}
+		return []string{string(q)}
+	}
+	name := strings.Replace(toIfaceStr(v), " ", "", -1)
+	if dot, _ := c.globalStore["dotIsSeparator"].(bool); !dot {
+		return []string{name}
+	}
+	var names []string
+	for _, n := range strings.Split(name, ".") {
+		if n != "" {
+			names = append(names, n)
+		}
+	}
+	return names
 }
 
 type mask interface {
@@ -49,7 +172,7 @@ func (t termGroup) paths() [][]string {
 	for _, m := range t.masks {
 		for _, p := range m.paths() {
 			v := append([]string{}, t.name...)
-			masks = append(masks, append(append(v, p...)))
+			masks = append(masks, append(v, p...))
 		}
 	}
 	return masks
@@ -98,23 +221,23 @@ var g = &grammar{
 	rules: []*rule{
 		{
 			name: "Masks",
-			pos:  position{line: 103, col: 1, offset: 2517},
+			pos:  position{line: 226, col: 1, offset: 7338},
 			expr: &actionExpr{
-				pos: position{line: 103, col: 9, offset: 2525},
+				pos: position{line: 226, col: 9, offset: 7346},
 				run: (*parser).callonMasks1,
 				expr: &seqExpr{
-					pos: position{line: 103, col: 9, offset: 2525},
+					pos: position{line: 226, col: 9, offset: 7346},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 103, col: 9, offset: 2525},
+							pos:   position{line: 226, col: 9, offset: 7346},
 							label: "val",
 							expr: &ruleRefExpr{
-								pos:  position{line: 103, col: 14, offset: 2530},
+								pos:  position{line: 226, col: 14, offset: 7351},
 								name: "Value",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 103, col: 20, offset: 2536},
+							pos:  position{line: 226, col: 20, offset: 7357},
 							name: "EOF",
 						},
 					},
@@ -123,43 +246,50 @@ var g = &grammar{
 		},
 		{
 			name: "Value",
-			pos:  position{line: 107, col: 1, offset: 2580},
+			pos:  position{line: 230, col: 1, offset: 7401},
 			expr: &actionExpr{
-				pos: position{line: 107, col: 9, offset: 2588},
+				pos: position{line: 230, col: 9, offset: 7409},
 				run: (*parser).callonValue1,
 				expr: &seqExpr{
-					pos: position{line: 107, col: 9, offset: 2588},
+					pos: position{line: 230, col: 9, offset: 7409},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 107, col: 9, offset: 2588},
+							pos:   position{line: 230, col: 9, offset: 7409},
 							label: "val",
 							expr: &choiceExpr{
-								pos: position{line: 107, col: 15, offset: 2594},
+								pos: position{line: 230, col: 15, offset: 7415},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 107, col: 15, offset: 2594},
+										pos:  position{line: 230, col: 15, offset: 7415},
 										name: "TermArray",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 107, col: 27, offset: 2606},
+										pos:  position{line: 230, col: 27, offset: 7427},
 										name: "TermValue",
 									},
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 107, col: 38, offset: 2617},
+							pos:  position{line: 230, col: 38, offset: 7438},
 							name: "_",
 						},
+						&zeroOrOneExpr{
+							pos: position{line: 230, col: 40, offset: 7440},
+							expr: &ruleRefExpr{
+								pos:  position{line: 230, col: 40, offset: 7440},
+								name: "TrailingComma",
+							},
+						},
 					},
 				},
 			},
 		},
 		{
 			name: "WildCard",
-			pos:  position{line: 111, col: 1, offset: 2644},
+			pos:  position{line: 234, col: 1, offset: 7480},
 			expr: &litMatcher{
-				pos:        position{line: 111, col: 12, offset: 2655},
+				pos:        position{line: 234, col: 12, offset: 7491},
 				val:        "*",
 				ignoreCase: false,
 				want:       "\"*\"",
@@ -167,14 +297,14 @@ var g = &grammar{
 		},
 		{
 			name: "Identifier",
-			pos:  position{line: 113, col: 1, offset: 2660},
+			pos:  position{line: 236, col: 1, offset: 7496},
 			expr: &actionExpr{
-				pos: position{line: 113, col: 14, offset: 2673},
+				pos: position{line: 236, col: 14, offset: 7509},
 				run: (*parser).callonIdentifier1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 113, col: 14, offset: 2673},
+					pos: position{line: 236, col: 14, offset: 7509},
 					expr: &charClassMatcher{
-						pos:        position{line: 113, col: 14, offset: 2673},
+						pos:        position{line: 236, col: 14, offset: 7509},
 						val:        "[^: \\t\\r\\n)(/,]",
 						chars:      []rune{':', ' ', '\t', '\r', '\n', ')', '(', '/', ','},
 						ignoreCase: false,
@@ -185,20 +315,20 @@ var g = &grammar{
 		},
 		{
 			name: "TermPath",
-			pos:  position{line: 117, col: 1, offset: 2726},
+			pos:  position{line: 240, col: 1, offset: 7562},
 			expr: &choiceExpr{
-				pos: position{line: 117, col: 12, offset: 2737},
+				pos: position{line: 240, col: 12, offset: 7573},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 117, col: 12, offset: 2737},
+						pos:  position{line: 240, col: 12, offset: 7573},
 						name: "QuotedTerm",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 117, col: 25, offset: 2750},
+						pos:  position{line: 240, col: 25, offset: 7586},
 						name: "Identifier",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 117, col: 38, offset: 2763},
+						pos:  position{line: 240, col: 38, offset: 7599},
 						name: "WildCard",
 					},
 				},
@@ -206,49 +336,49 @@ var g = &grammar{
 		},
 		{
 			name: "Path",
-			pos:  position{line: 119, col: 1, offset: 2773},
+			pos:  position{line: 242, col: 1, offset: 7609},
 			expr: &actionExpr{
-				pos: position{line: 119, col: 8, offset: 2780},
+				pos: position{line: 242, col: 8, offset: 7616},
 				run: (*parser).callonPath1,
 				expr: &seqExpr{
-					pos: position{line: 119, col: 8, offset: 2780},
+					pos: position{line: 242, col: 8, offset: 7616},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 119, col: 8, offset: 2780},
+							pos:   position{line: 242, col: 8, offset: 7616},
 							label: "id",
 							expr: &ruleRefExpr{
-								pos:  position{line: 119, col: 11, offset: 2783},
+								pos:  position{line: 242, col: 11, offset: 7619},
 								name: "TermPath",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 119, col: 20, offset: 2792},
+							pos:  position{line: 242, col: 20, offset: 7628},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 119, col: 22, offset: 2794},
+							pos:   position{line: 242, col: 22, offset: 7630},
 							label: "vals",
 							expr: &oneOrMoreExpr{
-								pos: position{line: 119, col: 27, offset: 2799},
+								pos: position{line: 242, col: 27, offset: 7635},
 								expr: &seqExpr{
-									pos: position{line: 119, col: 28, offset: 2800},
+									pos: position{line: 242, col: 28, offset: 7636},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 119, col: 28, offset: 2800},
+											pos:        position{line: 242, col: 28, offset: 7636},
 											val:        "/",
 											ignoreCase: false,
 											want:       "\"/\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 119, col: 31, offset: 2803},
+											pos:  position{line: 242, col: 31, offset: 7639},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 119, col: 33, offset: 2805},
+											pos:  position{line: 242, col: 33, offset: 7641},
 											name: "TermPath",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 119, col: 42, offset: 2814},
+											pos:  position{line: 242, col: 42, offset: 7650},
 											name: "_",
 										},
 									},
@@ -261,62 +391,62 @@ var g = &grammar{
 		},
 		{
 			name: "Term",
-			pos:  position{line: 128, col: 1, offset: 3033},
+			pos:  position{line: 251, col: 1, offset: 7837},
 			expr: &actionExpr{
-				pos: position{line: 129, col: 3, offset: 3040},
+				pos: position{line: 252, col: 3, offset: 7844},
 				run: (*parser).callonTerm1,
 				expr: &seqExpr{
-					pos: position{line: 129, col: 3, offset: 3040},
+					pos: position{line: 252, col: 3, offset: 7844},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 129, col: 3, offset: 3040},
+							pos:  position{line: 252, col: 3, offset: 7844},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 129, col: 5, offset: 3042},
+							pos:   position{line: 252, col: 5, offset: 7846},
 							label: "id",
 							expr: &choiceExpr{
-								pos: position{line: 129, col: 9, offset: 3046},
+								pos: position{line: 252, col: 9, offset: 7850},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 129, col: 9, offset: 3046},
+										pos:  position{line: 252, col: 9, offset: 7850},
 										name: "QuotedTerm",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 129, col: 22, offset: 3059},
+										pos:  position{line: 252, col: 22, offset: 7863},
 										name: "Identifier",
 									},
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 129, col: 34, offset: 3071},
+							pos:  position{line: 252, col: 34, offset: 7875},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 129, col: 36, offset: 3073},
+							pos:   position{line: 252, col: 36, offset: 7877},
 							label: "vals",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 129, col: 41, offset: 3078},
+								pos: position{line: 252, col: 41, offset: 7882},
 								expr: &seqExpr{
-									pos: position{line: 129, col: 42, offset: 3079},
+									pos: position{line: 252, col: 42, offset: 7883},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 129, col: 42, offset: 3079},
+											pos:        position{line: 252, col: 42, offset: 7883},
 											val:        "/",
 											ignoreCase: false,
 											want:       "\"/\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 129, col: 46, offset: 3083},
+											pos:  position{line: 252, col: 46, offset: 7887},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 129, col: 48, offset: 3085},
+											pos:  position{line: 252, col: 48, offset: 7889},
 											name: "TermPath",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 129, col: 57, offset: 3094},
+											pos:  position{line: 252, col: 57, offset: 7898},
 											name: "_",
 										},
 									},
@@ -329,16 +459,16 @@ var g = &grammar{
 		},
 		{
 			name: "TermValue",
-			pos:  position{line: 143, col: 1, offset: 3511},
+			pos:  position{line: 262, col: 1, offset: 8110},
 			expr: &choiceExpr{
-				pos: position{line: 143, col: 13, offset: 3523},
+				pos: position{line: 262, col: 13, offset: 8122},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 143, col: 13, offset: 3523},
+						pos:  position{line: 262, col: 13, offset: 8122},
 						name: "TermGroup",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 143, col: 26, offset: 3536},
+						pos:  position{line: 262, col: 26, offset: 8135},
 						name: "Term",
 					},
 				},
@@ -346,75 +476,82 @@ var g = &grammar{
 		},
 		{
 			name: "TermGroup",
-			pos:  position{line: 145, col: 1, offset: 3542},
+			pos:  position{line: 264, col: 1, offset: 8141},
 			expr: &actionExpr{
-				pos: position{line: 146, col: 3, offset: 3554},
+				pos: position{line: 265, col: 3, offset: 8153},
 				run: (*parser).callonTermGroup1,
 				expr: &seqExpr{
-					pos: position{line: 146, col: 3, offset: 3554},
+					pos: position{line: 265, col: 3, offset: 8153},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 146, col: 3, offset: 3554},
+							pos:  position{line: 265, col: 3, offset: 8153},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 146, col: 5, offset: 3556},
+							pos:   position{line: 265, col: 5, offset: 8155},
 							label: "key",
 							expr: &choiceExpr{
-								pos: position{line: 146, col: 10, offset: 3561},
+								pos: position{line: 265, col: 10, offset: 8160},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 146, col: 10, offset: 3561},
+										pos:  position{line: 265, col: 10, offset: 8160},
 										name: "Path",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 146, col: 17, offset: 3568},
+										pos:  position{line: 265, col: 17, offset: 8167},
 										name: "QuotedTerm",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 146, col: 30, offset: 3581},
+										pos:  position{line: 265, col: 30, offset: 8180},
 										name: "Identifier",
 									},
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 146, col: 42, offset: 3593},
+							pos:  position{line: 265, col: 42, offset: 8192},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 146, col: 44, offset: 3595},
+							pos:        position{line: 265, col: 44, offset: 8194},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 146, col: 48, offset: 3599},
+							pos:  position{line: 265, col: 48, offset: 8198},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 146, col: 50, offset: 3601},
+							pos:   position{line: 265, col: 50, offset: 8200},
 							label: "vals",
 							expr: &choiceExpr{
-								pos: position{line: 146, col: 56, offset: 3607},
+								pos: position{line: 265, col: 56, offset: 8206},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 146, col: 56, offset: 3607},
+										pos:  position{line: 265, col: 56, offset: 8206},
 										name: "TermArray",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 146, col: 68, offset: 3619},
+										pos:  position{line: 265, col: 68, offset: 8218},
 										name: "TermValue",
 									},
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 146, col: 79, offset: 3630},
+							pos:  position{line: 265, col: 79, offset: 8229},
 							name: "_",
 						},
+						&zeroOrOneExpr{
+							pos: position{line: 265, col: 81, offset: 8231},
+							expr: &ruleRefExpr{
+								pos:  position{line: 265, col: 81, offset: 8231},
+								name: "TrailingComma",
+							},
+						},
 						&litMatcher{
-							pos:        position{line: 146, col: 81, offset: 3632},
+							pos:        position{line: 265, col: 96, offset: 8246},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -425,41 +562,41 @@ var g = &grammar{
 		},
 		{
 			name: "TermArray",
-			pos:  position{line: 159, col: 1, offset: 3871},
+			pos:  position{line: 278, col: 1, offset: 8476},
 			expr: &actionExpr{
-				pos: position{line: 160, col: 3, offset: 3883},
+				pos: position{line: 279, col: 3, offset: 8488},
 				run: (*parser).callonTermArray1,
 				expr: &labeledExpr{
-					pos:   position{line: 160, col: 3, offset: 3883},
+					pos:   position{line: 279, col: 3, offset: 8488},
 					label: "vals",
 					expr: &seqExpr{
-						pos: position{line: 160, col: 9, offset: 3889},
+						pos: position{line: 279, col: 9, offset: 8494},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 160, col: 9, offset: 3889},
+								pos:  position{line: 279, col: 9, offset: 8494},
 								name: "TermValue",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 160, col: 19, offset: 3899},
+								pos:  position{line: 279, col: 19, offset: 8504},
 								name: "_",
 							},
 							&oneOrMoreExpr{
-								pos: position{line: 160, col: 21, offset: 3901},
+								pos: position{line: 279, col: 21, offset: 8506},
 								expr: &seqExpr{
-									pos: position{line: 160, col: 22, offset: 3902},
+									pos: position{line: 279, col: 22, offset: 8507},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 160, col: 22, offset: 3902},
+											pos:        position{line: 279, col: 22, offset: 8507},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 160, col: 26, offset: 3906},
+											pos:  position{line: 279, col: 26, offset: 8511},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 160, col: 28, offset: 3908},
+											pos:  position{line: 279, col: 28, offset: 8513},
 											name: "TermValue",
 										},
 									},
@@ -470,11 +607,34 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "TrailingComma",
+			pos:  position{line: 293, col: 1, offset: 8853},
+			expr: &seqExpr{
+				pos: position{line: 293, col: 17, offset: 8869},
+				exprs: []interface{}{
+					&andCodeExpr{
+						pos: position{line: 293, col: 17, offset: 8869},
+						run: (*parser).callonTrailingComma2,
+					},
+					&litMatcher{
+						pos:        position{line: 296, col: 3, offset: 8954},
+						val:        ",",
+						ignoreCase: false,
+						want:       "\",\"",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 296, col: 7, offset: 8958},
+						name: "_",
+					},
+				},
+			},
+		},
 		{
 			name: "EscapedChar",
-			pos:  position{line: 174, col: 1, offset: 4248},
+			pos:  position{line: 298, col: 1, offset: 8961},
 			expr: &charClassMatcher{
-				pos:        position{line: 174, col: 16, offset: 4263},
+				pos:        position{line: 298, col: 16, offset: 8976},
 				val:        "[\\x00-\\x1f\"\\\\]",
 				chars:      []rune{'"', '\\'},
 				ranges:     []rune{'\x00', '\x1f'},
@@ -484,16 +644,16 @@ var g = &grammar{
 		},
 		{
 			name: "EscapeSequence",
-			pos:  position{line: 176, col: 1, offset: 4279},
+			pos:  position{line: 300, col: 1, offset: 8992},
 			expr: &choiceExpr{
-				pos: position{line: 176, col: 19, offset: 4297},
+				pos: position{line: 300, col: 19, offset: 9010},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 176, col: 19, offset: 4297},
+						pos:  position{line: 300, col: 19, offset: 9010},
 						name: "SingleCharEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 176, col: 38, offset: 4316},
+						pos:  position{line: 300, col: 38, offset: 9029},
 						name: "UnicodeEscape",
 					},
 				},
@@ -501,9 +661,9 @@ var g = &grammar{
 		},
 		{
 			name: "SingleCharEscape",
-			pos:  position{line: 178, col: 1, offset: 4331},
+			pos:  position{line: 302, col: 1, offset: 9044},
 			expr: &charClassMatcher{
-				pos:        position{line: 178, col: 21, offset: 4351},
+				pos:        position{line: 302, col: 21, offset: 9064},
 				val:        "[\"\\\\/bfnrt]",
 				chars:      []rune{'"', '\\', '/', 'b', 'f', 'n', 'r', 't'},
 				ignoreCase: false,
@@ -512,50 +672,50 @@ var g = &grammar{
 		},
 		{
 			name: "QuotedTerm",
-			pos:  position{line: 180, col: 1, offset: 4364},
+			pos:  position{line: 304, col: 1, offset: 9077},
 			expr: &actionExpr{
-				pos: position{line: 181, col: 5, offset: 4379},
+				pos: position{line: 305, col: 5, offset: 9092},
 				run: (*parser).callonQuotedTerm1,
 				expr: &seqExpr{
-					pos: position{line: 181, col: 5, offset: 4379},
+					pos: position{line: 305, col: 5, offset: 9092},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 181, col: 5, offset: 4379},
+							pos:        position{line: 305, col: 5, offset: 9092},
 							val:        "\"",
 							ignoreCase: false,
 							want:       "\"\\\"\"",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 181, col: 9, offset: 4383},
+							pos: position{line: 305, col: 9, offset: 9096},
 							expr: &choiceExpr{
-								pos: position{line: 181, col: 10, offset: 4384},
+								pos: position{line: 305, col: 10, offset: 9097},
 								alternatives: []interface{}{
 									&seqExpr{
-										pos: position{line: 181, col: 10, offset: 4384},
+										pos: position{line: 305, col: 10, offset: 9097},
 										exprs: []interface{}{
 											&notExpr{
-												pos: position{line: 181, col: 10, offset: 4384},
+												pos: position{line: 305, col: 10, offset: 9097},
 												expr: &ruleRefExpr{
-													pos:  position{line: 181, col: 11, offset: 4385},
+													pos:  position{line: 305, col: 11, offset: 9098},
 													name: "EscapedChar",
 												},
 											},
 											&anyMatcher{
-												line: 181, col: 23, offset: 4397,
+												line: 305, col: 23, offset: 9110,
 											},
 										},
 									},
 									&seqExpr{
-										pos: position{line: 181, col: 27, offset: 4401},
+										pos: position{line: 305, col: 27, offset: 9114},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 181, col: 27, offset: 4401},
+												pos:        position{line: 305, col: 27, offset: 9114},
 												val:        "\\",
 												ignoreCase: false,
 												want:       "\"\\\\\"",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 181, col: 32, offset: 4406},
+												pos:  position{line: 305, col: 32, offset: 9119},
 												name: "EscapeSequence",
 											},
 										},
@@ -564,7 +724,7 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 181, col: 49, offset: 4423},
+							pos:        position{line: 305, col: 49, offset: 9136},
 							val:        "\"",
 							ignoreCase: false,
 							want:       "\"\\\"\"",
@@ -576,11 +736,11 @@ var g = &grammar{
 		{
 			name:        "_",
 			displayName: "\"whitespace\"",
-			pos:         position{line: 187, col: 1, offset: 4557},
+			pos:         position{line: 312, col: 1, offset: 9307},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 187, col: 18, offset: 4574},
+				pos: position{line: 312, col: 18, offset: 9324},
 				expr: &charClassMatcher{
-					pos:        position{line: 187, col: 18, offset: 4574},
+					pos:        position{line: 312, col: 18, offset: 9324},
 					val:        "[ \\t\\r\\n]",
 					chars:      []rune{' ', '\t', '\r', '\n'},
 					ignoreCase: false,
@@ -590,11 +750,11 @@ var g = &grammar{
 		},
 		{
 			name: "EOF",
-			pos:  position{line: 189, col: 1, offset: 4586},
+			pos:  position{line: 314, col: 1, offset: 9336},
 			expr: &notExpr{
-				pos: position{line: 189, col: 7, offset: 4592},
+				pos: position{line: 314, col: 7, offset: 9342},
 				expr: &anyMatcher{
-					line: 189, col: 8, offset: 4593,
+					line: 314, col: 8, offset: 9343,
 				},
 			},
 		},
@@ -632,10 +792,10 @@ func (p *parser) callonIdentifier1() (interface{}, error) {
 }
 
 func (c *current) onPath1(id, vals interface{}) (interface{}, error) {
-	names := []string{toIfaceStr(id)}
+	names := segments(c, id)
 	for _, v := range toIfaceSlice(vals) {
 		sl := toIfaceSlice(v)
-		names = append(names, strings.Replace(sl[2].(string), " ", "", -1))
+		names = append(names, segments(c, sl[2])...)
 	}
 	return names, nil
 }
@@ -647,14 +807,10 @@ func (p *parser) callonPath1() (interface{}, error) {
 }
 
 func (c *current) onTerm1(id, vals interface{}) (interface{}, error) {
-	valsSl := toIfaceSlice(vals)
-	if len(valsSl) == 0 {
-		return termMask{name: []string{strings.Replace(toIfaceStr(id), " ", "", -1)}}, nil
-	}
-	names := []string{strings.Replace(toIfaceStr(id), " ", "", -1)}
-	for _, v := range valsSl {
+	names := segments(c, id)
+	for _, v := range toIfaceSlice(vals) {
 		vSl := toIfaceSlice(v)
-		names = append(names, strings.Replace(vSl[2].(string), " ", "", -1))
+		names = append(names, segments(c, vSl[2])...)
 	}
 	return termMask{name: names}, nil
 }
@@ -670,7 +826,7 @@ func (c *current) onTermGroup1(key, vals interface{}) (interface{}, error) {
 	if v, ok := key.([]string); ok {
 		names = v
 	} else {
-		names = []string{toIfaceStr(key)}
+		names = segments(c, key)
 	}
 	return termGroup{
 		name:  names,
@@ -704,9 +860,21 @@ func (p *parser) callonTermArray1() (interface{}, error) {
 	return p.cur.onTermArray1(stack["vals"])
 }
 
+func (c *current) onTrailingComma2() (bool, error) {
+	allowed, _ := c.globalStore["trailingComma"].(bool)
+	return allowed, nil
+}
+
+func (p *parser) callonTrailingComma2() (bool, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onTrailingComma2()
+}
+
 func (c *current) onQuotedTerm1() (interface{}, error) {
 	c.text = bytes.Replace(c.text, []byte(`\/`), []byte(`/`), -1)
-	return strconv.Unquote(string(c.text))
+	s, err := strconv.Unquote(string(c.text))
+	return quotedTerm(s), err
 
 }
 
@@ -1,7 +1,10 @@
 package fieldmask
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -14,6 +17,11 @@ func TestMaskExtract(t *testing.T) {
 		`labels("techaid.tech/uuid")`:               [][]string{{"labels", "techaid.tech/uuid"}},
 		`labels/"techaid.tech/uuid"`:                [][]string{{"labels", "techaid.tech/uuid"}},
 		`"labels/techaid.tech/uuid"`:                [][]string{{"labels/techaid.tech/uuid"}},
+		`labels("a,b")`:                             [][]string{{"labels", "a,b"}},
+		`labels("x(y)")`:                            [][]string{{"labels", "x(y)"}},
+		`labels("a,b", "x(y)"),etag`:                [][]string{{"labels", "a,b"}, {"labels", "x(y)"}, {"etag"}},
+		`"a)b"(c)`:                                  [][]string{{"a)b", "c"}},
+		`labels/"say \"hi\", (now)"`:                [][]string{{"labels", `say "hi", (now)`}},
 		"items(id)":                                 [][]string{{"items", "id"}},
 		"context/facets/label":                      [][]string{{"context", "facets", "label"}},
 		"context.facets.label,items(id)":            [][]string{{"context.facets.label"}, {"items", "id"}},
@@ -29,4 +37,135 @@ func TestMaskExtract(t *testing.T) {
 		assert.NoError(t, err, q)
 		assert.Equal(t, expected, got, q)
 	}
-}
\ No newline at end of file
+}
+
+func TestMaskLimits(t *testing.T) {
+	cases := []struct {
+		query string
+		opt   MasksOptions
+		err   error
+	}{
+		{query: "a(b(c(d(e))))", opt: MasksOptions{MaxDepth: 5}},
+		{query: "a(b(c(d(e))))", opt: MasksOptions{MaxDepth: 4}, err: ErrMaxDepthExceeded},
+		{query: "a/b/c/d/e", opt: MasksOptions{MaxDepth: 4}, err: ErrMaxDepthExceeded},
+		{query: "a,b,c(d,e)", opt: MasksOptions{MaxPaths: 4}},
+		{query: "a,b,c(d,e,f)", opt: MasksOptions{MaxPaths: 4}, err: ErrMaxPathsExceeded},
+		{query: "a,b,c(d,e,f)"},
+		{query: `a("b(c(d")`, opt: MasksOptions{MaxDepth: 2}},
+		{query: strings.Repeat("a(", 50) + "b" + strings.Repeat(")", 50), opt: MasksOptions{MaxDepth: 10}, err: ErrMaxDepthExceeded},
+	}
+	for _, dt := range cases {
+		got, err := Masks(dt.query, dt.opt)
+		if dt.err == nil {
+			assert.NoError(t, err, dt.query)
+			assert.NotEmpty(t, got, dt.query)
+			continue
+		}
+		assert.True(t, errors.Is(err, dt.err), "%s: %v", dt.query, err)
+		assert.Empty(t, got, dt.query)
+	}
+}
+
+func TestDeepMasks(t *testing.T) {
+	for _, depth := range []int{16, 50, 1000} {
+		query := strings.Repeat("a(", depth) + "b" + strings.Repeat(")", depth)
+		start := time.Now()
+		_, err := Masks(query, MasksOptions{MaxDepth: 8})
+		assert.True(t, errors.Is(err, ErrMaxDepthExceeded), err)
+
+		got, err := Masks(query)
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.Len(t, got[0], depth+1)
+		assert.True(t, time.Since(start) < time.Second, "%d levels parsed in %s", depth, time.Since(start))
+	}
+}
+
+func TestMaskTrailingComma(t *testing.T) {
+	cases := []struct {
+		query    string
+		expected [][]string
+	}{
+		{query: "items(id,title,)", expected: [][]string{{"items", "id"}, {"items", "title"}}},
+		{query: "items(id, title , ) ", expected: [][]string{{"items", "id"}, {"items", "title"}}},
+		{query: "items(id,)", expected: [][]string{{"items", "id"}}},
+		{query: "etag,items(id,author(uri,),),", expected: [][]string{{"etag"}, {"items", "id"}, {"items", "author", "uri"}}},
+		{query: "etag,", expected: [][]string{{"etag"}}},
+		{query: "etag,items", expected: [][]string{{"etag"}, {"items"}}},
+	}
+	for _, dt := range cases {
+		got, err := Masks(dt.query, MasksOptions{TrailingComma: true})
+		assert.NoError(t, err, dt.query)
+		assert.Equal(t, dt.expected, got, dt.query)
+
+		_, err = Masks(dt.query)
+		if dt.query != "etag,items" {
+			assert.Error(t, err, dt.query)
+		}
+	}
+
+	for _, query := range []string{"items(id,,)", ",", "items(,)", "etag,,"} {
+		_, err := Masks(query, MasksOptions{TrailingComma: true})
+		assert.Error(t, err, query)
+	}
+}
+
+func TestMaskDotSeparator(t *testing.T) {
+	cases := []struct {
+		query    string
+		dot      bool
+		expected [][]string
+	}{
+		{query: "a.b.c", expected: [][]string{{"a.b.c"}}},
+		{query: "a.b.c", dot: true, expected: [][]string{{"a", "b", "c"}}},
+		{query: "a.b/c.d", dot: true, expected: [][]string{{"a", "b", "c", "d"}}},
+		{query: "a.b(c.d,e)", dot: true, expected: [][]string{{"a", "b", "c", "d"}, {"a", "b", "e"}}},
+		{query: `labels("techaid.tech/uuid")`, dot: true, expected: [][]string{{"labels", "techaid.tech/uuid"}}},
+		{query: `labels/"techaid.tech"/uuid`, dot: true, expected: [][]string{{"labels", "techaid.tech", "uuid"}}},
+		{query: `labels/"techaid.tech"/uuid`, expected: [][]string{{"labels", "techaid.tech", "uuid"}}},
+	}
+	for _, dt := range cases {
+		got, err := Masks(dt.query, MasksOptions{DotIsSeparator: dt.dot})
+		assert.NoError(t, err, dt.query)
+		assert.Equal(t, dt.expected, got, dt.query)
+	}
+}
+
+func TestMaskCompact(t *testing.T) {
+	cases := []struct {
+		queries  []string
+		expected string
+	}{
+		{queries: []string{"items ( id )", "items(id)", "items/id", " items / id "}, expected: "items/id"},
+		{queries: []string{"etag, items", "etag,items"}, expected: "etag,items"},
+		{
+			queries:  []string{"items(title, author/uri)", "items/title,items/author/uri", " items ( title , author ( uri ) ) "},
+			expected: "items/title,items/author/uri",
+		},
+		{queries: []string{"  links /* / href ", "links/*/href"}, expected: "links/*/href"},
+		{queries: []string{`labels("techaid.tech/uuid")`, `labels/"techaid.tech/uuid"`}, expected: `labels/"techaid.tech/uuid"`},
+		{queries: []string{`labels(techaid.tech/uuid)`, `labels/"techaid.tech"/uuid`}, expected: `labels/techaid.tech/uuid`},
+		{queries: []string{`labels("a,b", "x(y)")`, `labels/"a,b",labels/"x(y)"`}, expected: `labels/"a,b",labels/"x(y)"`},
+		{queries: []string{`labels/"*"`, `labels("*")`, ` labels / "*" `}, expected: `labels/"*"`},
+		{queries: []string{`"**"/id,items/*`, `"**"(id),items(*)`}, expected: `"**"/id,items/*`},
+	}
+	for _, dt := range cases {
+		for _, q := range dt.queries {
+			got, err := Compact(q)
+			assert.NoError(t, err, q)
+			assert.Equal(t, dt.expected, got, q)
+
+			paths, err := Masks(got)
+			assert.NoError(t, err, got)
+			expected, _ := Masks(q)
+			assert.Equal(t, expected, paths, got)
+
+			again, err := Compact(got)
+			assert.NoError(t, err, got)
+			assert.Equal(t, got, again, q)
+		}
+	}
+
+	_, err := Compact("items(id")
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package fieldmask
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Mask is a parsed field mask, which is applied to any number of values without parsing it again
+type Mask struct {
+	paths [][]string
+}
+
+// ParseMask parses the mask e.g. once for a request and projects every returned resource with it
+func ParseMask(mask string, opts ...MasksOptions) (Mask, error) {
+	paths, err := Masks(mask, opts...)
+	if err != nil {
+		return Mask{}, err
+	}
+	return Mask{paths: paths}, nil
+}
+
+// Paths returns the paths selected by the mask
+func (m Mask) Paths() [][]string {
+	return m.paths
+}
+
+// String returns the compact form of the mask
+func (m Mask) String() string {
+	return compact(m.paths)
+}
+
+// Project returns the value with only the fields selected by the mask. Data decoded from JSON is projected
+// as by Apply, JSON documents given as []byte or json.RawMessage are returned as JSON of the same type.
+// Structs and other values are projected through their JSON form, selecting their fields by the json tag
+// names and returning maps and slices with json.Number numbers so large integers keep their precision
+func (m Mask) Project(v interface{}) (interface{}, error) {
+	equal := func(segment, key string) bool {
+		return segment == key
+	}
+	switch data := v.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}, []interface{}:
+		value, _ := project(data, m.paths, equal)
+		return value, nil
+	case json.RawMessage:
+		b, err := m.projectJSON(data, equal)
+		return json.RawMessage(b), err
+	case []byte:
+		return m.projectJSON(data, equal)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	data, err := decodeJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	value, _ := project(data, m.paths, equal)
+	return value, nil
+}
+
+// projectJSON returns the JSON document with only the fields selected by the mask
+func (m Mask) projectJSON(b []byte, matcher KeyMatcher) ([]byte, error) {
+	data, err := decodeJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	value, _ := project(data, m.paths, matcher)
+	return json.Marshal(value)
+}
+
+// decodeJSON decodes the JSON document keeping its numbers as json.Number
+func decodeJSON(b []byte) (interface{}, error) {
+	var data interface{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
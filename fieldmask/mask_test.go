@@ -0,0 +1,82 @@
+package fieldmask
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type author struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+}
+
+type item struct {
+	ID     int64    `json:"id"`
+	Title  string   `json:"title"`
+	Author *author  `json:"author,omitempty"`
+	Tags   []string `json:"tags"`
+	secret string
+}
+
+func TestMaskProject(t *testing.T) {
+	m, err := ParseMask("id,author(name)")
+	assert.NoError(t, err)
+	assert.Equal(t, "id,author/name", m.String())
+	assert.Equal(t, [][]string{{"id"}, {"author", "name"}}, m.Paths())
+
+	got, err := m.Project(map[string]interface{}{
+		"id":     1.0,
+		"title":  "Go",
+		"author": map[string]interface{}{"name": "peter", "email": "peter@example.com"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"id":     1.0,
+		"author": map[string]interface{}{"name": "peter"},
+	}, got)
+
+	got, err = m.Project(item{
+		ID:     9007199254740993,
+		Title:  "Go",
+		Author: &author{Name: "peter", Email: "peter@example.com"},
+		Tags:   []string{"go"},
+		secret: "hidden",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"id":     json.Number("9007199254740993"),
+		"author": map[string]interface{}{"name": "peter"},
+	}, got)
+
+	got, err = m.Project([]*item{{ID: 1, Title: "Go"}, {ID: 2, Author: &author{Name: "paul"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": json.Number("1")},
+		map[string]interface{}{"id": json.Number("2"), "author": map[string]interface{}{"name": "paul"}},
+	}, got)
+
+	got, err = m.Project(json.RawMessage(`{"id": 12345678901234567890, "title": "Go", "author": {"name": "peter"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, json.RawMessage(`{"author":{"name":"peter"},"id":12345678901234567890}`), got)
+
+	got, err = m.Project([]byte(`[{"id": 1, "title": "Go"}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`[{"id":1}]`), got)
+
+	got, err = m.Project(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	_, err = m.Project([]byte(`{"id":`))
+	assert.Error(t, err)
+	_, err = m.Project(make(chan int))
+	assert.Error(t, err)
+
+	_, err = ParseMask("items(id")
+	assert.Error(t, err)
+	_, err = ParseMask("a/b/c", MasksOptions{MaxDepth: 2})
+	assert.True(t, errors.Is(err, ErrMaxDepthExceeded))
+}
@@ -0,0 +1,51 @@
+package fieldmask
+
+// Merge returns the compact form of the union of the masks. Paths selected by another path
+// are dropped e.g. merging `items/*` with `items/id` returns `items/*` while merging `items/id`
+// with `items/title` returns `items/id,items/title`
+func Merge(masks ...string) (string, error) {
+	var paths [][]string
+	for _, mask := range masks {
+		p, err := Masks(mask)
+		if err != nil {
+			return "", err
+		}
+		paths = append(paths, p...)
+	}
+	var merged [][]string
+	for i, path := range paths {
+		subsumed := false
+		for j, other := range paths {
+			// of two paths selecting the same fields the first is kept
+			if i != j && subsumes(other, path) && (j < i || !subsumes(path, other)) {
+				subsumed = true
+				break
+			}
+		}
+		if !subsumed {
+			merged = append(merged, path)
+		}
+	}
+	return compact(merged), nil
+}
+
+// subsumes reports whether the mask path selects every field selected by the path,
+// unlike contains the wildcards of the path are only matched by the same or wider wildcards
+func subsumes(mask, path []string) bool {
+	switch {
+	case len(mask) == 0:
+		return true
+	case mask[0] == "**":
+		for i := range path {
+			if subsumes(mask[1:], path[i:]) {
+				return true
+			}
+		}
+		return len(mask) == 1
+	case len(path) == 0 || path[0] == "**":
+		return false
+	case mask[0] == "*" || mask[0] == path[0]:
+		return subsumes(mask[1:], path[1:])
+	}
+	return false
+}
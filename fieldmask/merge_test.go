@@ -0,0 +1,33 @@
+package fieldmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	cases := []struct {
+		masks    []string
+		expected string
+	}{
+		{[]string{"items/*", "items/id"}, "items/*"},
+		{[]string{"items/id", "items/*"}, "items/*"},
+		{[]string{"items/id", "items/title"}, "items/id,items/title"},
+		{[]string{"items(id,title)", "items/id"}, "items/id,items/title"},
+		{[]string{"items", "items/author/uri"}, "items"},
+		{[]string{"items/*", "items"}, "items"},
+		{[]string{"items/*/id", "items/*", "etag"}, "items/*,etag"},
+		{[]string{"items/*", "items/**"}, "items/**"},
+		{[]string{"items/**/id", "items/author/id", "items/author/name"}, "items/**/id,items/author/name"},
+		{[]string{`labels("a,b")`, `labels/"a,b"`}, `labels/"a,b"`},
+	}
+	for _, tc := range cases {
+		got, err := Merge(tc.masks...)
+		assert.NoError(t, err, tc.masks)
+		assert.Equal(t, tc.expected, got, tc.masks)
+	}
+
+	_, err := Merge("items", "items(id")
+	assert.Error(t, err)
+}
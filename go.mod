@@ -3,6 +3,7 @@ module github.com/stevejuma/pkg
 go 1.17
 
 require (
+	github.com/Masterminds/squirrel v1.5.4
 	github.com/andreyvit/diff v0.0.0-20170406064948-c7f18ee00883
 	github.com/google/go-cmp v0.5.6
 	github.com/sirupsen/logrus v1.8.1
@@ -11,6 +12,8 @@ require (
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/lann/builder v0.0.0-20180802200727-47ae307949d0 // indirect
+	github.com/lann/ps v0.0.0-20150810152359-62de8c46ede0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/sergi/go-diff v1.2.0 // indirect
 	golang.org/x/sys v0.0.0-20191026070338-33540a1f6037 // indirect
@@ -0,0 +1,115 @@
+package lucenequery
+
+import (
+	"strings"
+)
+
+// Builder constructs a query tree without parsing a query string. The tree is the same
+// as the one returned by Parse for the equivalent query e.g.
+//
+//	New().Term("status", "open").And().Range("age", 18, 25).Build()
+//
+// is equal to the parsed `status: open AND age: [18 TO 25]`
+type Builder struct {
+	nodes  []interface{}
+	ops    []string
+	op     string
+	negate bool
+}
+
+// New returns an empty query builder
+func New() *Builder {
+	return &Builder{}
+}
+
+// Term matches the field with the value, a nil value matches a null field
+func (b *Builder) Term(field string, value interface{}) *Builder {
+	return b.add(TermQuery{Term: field, Value: value})
+}
+
+// Range matches the field with the inclusive range of values, a nil bound leaves the range open
+func (b *Builder) Range(field string, min, max interface{}) *Builder {
+	if min == nil {
+		min = "*"
+	}
+	if max == nil {
+		max = "*"
+	}
+	return b.add(RangeQuery{Term: field, Min: min, Max: max, Inclusive: true})
+}
+
+// Wildcard matches the field with a pattern containing `*` wildcards e.g. `gopher*`
+func (b *Builder) Wildcard(field, pattern string) *Builder {
+	var wc WildCardQuery
+	switch parts := strings.SplitN(pattern, "*", 3); {
+	case len(parts) == 3 && parts[0] == "":
+		wc.Term = parts[1]
+	case len(parts) >= 2:
+		wc.Prefix, wc.Suffix = parts[0], strings.TrimPrefix(pattern[len(parts[0]):], "*")
+	default:
+		return b.Term(field, pattern)
+	}
+	return b.add(TermQuery{Term: field, Value: wc})
+}
+
+// In matches the field with any of the values
+func (b *Builder) In(field string, values ...interface{}) *Builder {
+	if values == nil {
+		values = []interface{}{}
+	}
+	return b.add(TermQuery{Term: field, Op: "in", Value: values})
+}
+
+// Group adds the query of the builder as a parenthesized group
+func (b *Builder) Group(query *Builder) *Builder {
+	return b.add(query.Build())
+}
+
+// And joins the previous and the next node with AND
+func (b *Builder) And() *Builder {
+	b.op = "AND"
+	return b
+}
+
+// Or joins the previous and the next node with OR
+func (b *Builder) Or() *Builder {
+	b.op = "OR"
+	return b
+}
+
+// Not negates the next node
+func (b *Builder) Not() *Builder {
+	b.negate = !b.negate
+	return b
+}
+
+// Build returns the query tree, nodes not joined by an operator are joined with IMPLICIT.
+// Nil is returned when the builder has no nodes
+func (b *Builder) Build() interface{} {
+	if len(b.nodes) == 0 {
+		return nil
+	}
+	// the nodes are nested to the right as done by the parser: a AND b OR c => a AND (b OR c)
+	node := b.nodes[len(b.nodes)-1]
+	for i := len(b.nodes) - 2; i >= 0; i-- {
+		node = BooleanExpression{Op: b.ops[i], Args: []interface{}{b.nodes[i], node}}
+	}
+	return node
+}
+
+// add appends the node joined to the previous node with the pending operator
+func (b *Builder) add(node interface{}) *Builder {
+	if b.negate {
+		node = negate(node)
+	}
+	if len(b.nodes) > 0 {
+		op := b.op
+		if op == "" {
+			op = "IMPLICIT"
+		}
+		b.ops = append(b.ops, op)
+	}
+	b.nodes = append(b.nodes, node)
+	b.op, b.negate = "", false
+	return b
+}
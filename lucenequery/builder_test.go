@@ -0,0 +1,40 @@
+package lucenequery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	cases := []struct {
+		query   string
+		builder *Builder
+	}{
+		{`status: open`, New().Term("status", "open")},
+		{`status: open AND age: [18 TO 25]`, New().Term("status", "open").And().Range("age", 18, 25)},
+		{`age: [18 TO *]`, New().Range("age", 18, nil)},
+		{`title: go body: rust`, New().Term("title", "go").Term("body", "rust")},
+		{`body: gopher* OR body: *pher OR body: *oph* OR body: go*er`, New().
+			Wildcard("body", "gopher*").Or().
+			Wildcard("body", "*pher").Or().
+			Wildcard("body", "*oph*").Or().
+			Wildcard("body", "go*er")},
+		{`status: ["open", "closed"]`, New().In("status", "open", "closed")},
+		{`deleted: null`, New().Term("deleted", nil)},
+		{`-status: open`, New().Not().Term("status", "open")},
+		{`title: go AND NOT (body: rust OR body: c)`, New().
+			Term("title", "go").And().
+			Not().Group(New().Term("body", "rust").Or().Term("body", "c"))},
+		{`(a: 1 OR b: 2) AND c: 3 OR d: 4`, New().
+			Group(New().Term("a", 1).Or().Term("b", 2)).And().
+			Term("c", 3).Or().
+			Term("d", 4)},
+	}
+	for _, tc := range cases {
+		expected, err := Parse("TestBuilder", []byte(tc.query))
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, expected, tc.builder.Build(), tc.query)
+	}
+	assert.Nil(t, New().Build())
+}
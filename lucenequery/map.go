@@ -0,0 +1,71 @@
+package lucenequery
+
+// ParseToMap parses the query returning its AST as plain maps and slices e.g. for exposing the parser
+// across a JSON-RPC or FFI boundary. Every node has a `type` discriminator (boolean, term, range) and
+// the keys of the JSON form of its struct e.g. `{"type": "term", "term": "name", "value": "peter"}`.
+// Wildcard and relative time values are maps with the `wildcard` and `time` types, a root of several
+// nodes is returned as an IMPLICIT boolean
+func ParseToMap(input string, opts ...Option) (map[string]interface{}, error) {
+	node, err := Parse("ParseToMap", []byte(input), opts...)
+	if err != nil {
+		return nil, err
+	}
+	if nodes, ok := node.([]interface{}); ok {
+		node = BooleanExpression{Op: "IMPLICIT", Args: nodes}
+	}
+	m, _ := toMap(node).(map[string]interface{})
+	return m, nil
+}
+
+// toMap returns the node or value as plain maps and slices
+func toMap(node interface{}) interface{} {
+	switch v := node.(type) {
+	case *BooleanExpression:
+		return toMap(*v)
+	case *TermQuery:
+		return toMap(*v)
+	case *RangeQuery:
+		return toMap(*v)
+	case BooleanExpression:
+		args := make([]interface{}, len(v.Args))
+		for i, arg := range v.Args {
+			args[i] = toMap(arg)
+		}
+		return map[string]interface{}{"type": "boolean", "op": v.Op, "args": args}
+	case TermQuery:
+		m := map[string]interface{}{"type": "term", "term": v.Term, "value": toMap(v.Value)}
+		if v.Prefix != "" {
+			m["prefix"] = v.Prefix
+		}
+		if v.Op != "" {
+			m["op"] = v.Op
+		}
+		if v.Cast != "" {
+			m["cast"] = v.Cast
+		}
+		return m
+	case RangeQuery:
+		m := map[string]interface{}{
+			"type":      "range",
+			"term":      v.Term,
+			"min":       toMap(v.Min),
+			"max":       toMap(v.Max),
+			"inclusive": v.Inclusive,
+		}
+		if v.Cast != "" {
+			m["cast"] = v.Cast
+		}
+		return m
+	case WildCardQuery:
+		return map[string]interface{}{"type": "wildcard", "prefix": v.Prefix, "suffix": v.Suffix, "term": v.Term}
+	case TimeAnchor:
+		return map[string]interface{}{"type": "time", "value": string(v)}
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, value := range v {
+			values[i] = toMap(value)
+		}
+		return values
+	}
+	return node
+}
@@ -0,0 +1,63 @@
+package lucenequery
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseToMap(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		`name: peter`: {"type": "term", "term": "name", "value": "peter"},
+		`-status: closed`: {
+			"type": "term", "term": "status", "value": "closed", "prefix": "-",
+		},
+		`tags: ["go", "sql"]`: {
+			"type": "term", "term": "tags", "value": []interface{}{"go", "sql"}, "op": "in",
+		},
+		`name: pet*`: {
+			"type": "term", "term": "name",
+			"value": map[string]interface{}{"type": "wildcard", "prefix": "pet", "suffix": "", "term": ""},
+		},
+		`age: [18 TO 30]`: {"type": "range", "term": "age", "min": 18, "max": 30, "inclusive": true},
+		`id::int: "5"`:    {"type": "term", "term": "id", "value": "5", "cast": "int"},
+		`id::int: [1 TO 5]`: {
+			"type": "range", "term": "id", "min": 1, "max": 5, "inclusive": true, "cast": "int",
+		},
+		`created: >= yesterday`: {
+			"type": "range", "term": "created", "min": map[string]interface{}{"type": "time", "value": "yesterday"},
+			"max": "*", "inclusive": true,
+		},
+		`name: peter AND (age: > 18 OR role: admin)`: {
+			"type": "boolean",
+			"op":   "AND",
+			"args": []interface{}{
+				map[string]interface{}{"type": "term", "term": "name", "value": "peter"},
+				map[string]interface{}{
+					"type": "boolean",
+					"op":   "OR",
+					"args": []interface{}{
+						map[string]interface{}{"type": "range", "term": "age", "min": 18, "max": "*", "inclusive": false},
+						map[string]interface{}{"type": "term", "term": "role", "value": "admin"},
+					},
+				},
+			},
+		},
+	}
+	for q, expected := range cases {
+		got, err := ParseToMap(q)
+		if err != nil {
+			t.Fatalf("Expected to parse %s without error, got: %v", q, err)
+		}
+		if !reflect.DeepEqual(expected, got) {
+			t.Errorf("Expected lucenequery `%s` to equal %#v, got %#v", q, expected, got)
+		}
+		if _, err := json.Marshal(got); err != nil {
+			t.Errorf("Expected lucenequery `%s` to marshal without error, got: %v", q, err)
+		}
+	}
+
+	if _, err := ParseToMap(""); err == nil {
+		t.Errorf("Expected an empty lucenequery to fail")
+	}
+}
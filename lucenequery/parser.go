@@ -14,6 +14,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 	"unicode"
 	"unicode/utf8"
 )
@@ -45,6 +46,219 @@ func toIfaceSlice(v interface{}) []interface{} {
 	return v.([]interface{})
 }
 
+// byteSizeUnits maps the supported byte size suffixes to the power of the base they represent
+var byteSizeUnits = map[string]float64{
+	"kb": 1,
+	"mb": 2,
+	"gb": 3,
+}
+
+// WithByteSizeBase sets the base used when expanding byte size values such as `10mb`.
+// Values are expanded using binary (1024) units by default, use 1000 for decimal units
+func WithByteSizeBase(base int) Option {
+	return GlobalStore("byteSizeBase", base)
+}
+
+// DefaultWordOperators are the word forms of the equality comparators enabled by WithWordOperators
+var DefaultWordOperators = map[string]string{
+	"equals":                "eq",
+	"not_equal":             "neq",
+	"greater_than":          "gt",
+	"greater_than_or_equal": "gte",
+	"less_than":             "lt",
+	"less_than_or_equal":    "lte",
+}
+
+// WithWordOperators enables comparators spelled as words e.g. `age: greater_than 18`.
+// The operators map the (case insensitive) words to the canonical operator (eq/neq/gt/gte/lt/lte)
+// and DefaultWordOperators is used when none are provided
+func WithWordOperators(operators map[string]string) Option {
+	if operators == nil {
+		operators = DefaultWordOperators
+	}
+	ops := make(map[string]string, len(operators))
+	for word, op := range operators {
+		ops[strings.ToLower(word)] = op
+	}
+	return GlobalStore("wordOperators", ops)
+}
+
+// wordOperator returns the canonical operator for the word if word operators are enabled
+func wordOperator(c *current, word string) (string, bool) {
+	ops, _ := c.globalStore["wordOperators"].(map[string]string)
+	op, ok := ops[strings.ToLower(word)]
+	return op, ok
+}
+
+// WithDefaultField sets the field of the terms and ranges that do not name a field
+// e.g. `>= 5` is parsed as `age: >= 5` for the default field age
+func WithDefaultField(field string) Option {
+	return GlobalStore("defaultField", field)
+}
+
+// ErrFieldRequired is returned for terms without a field when WithRequireField is set
+var ErrFieldRequired = errors.New("field required")
+
+// WithRequireField rejects queries with terms that do not name a field e.g. `foo` instead of `name:foo`
+func WithRequireField() Option {
+	return GlobalStore("requireField", true)
+}
+
+// requireField returns an error for the first term of the node without a field when required
+func requireField(c *current, node interface{}) error {
+	if required, _ := c.globalStore["requireField"].(bool); !required {
+		return nil
+	}
+	var err error
+	Walk(node, func(n interface{}) bool {
+		if err != nil {
+			return false
+		}
+		switch v := n.(type) {
+		case TermQuery:
+			if v.Term == "" {
+				value := fmt.Sprintf("%v", v.Value)
+				if terms := termValues(v.Term, v.Value); len(terms) > 0 {
+					value = terms[0].Value
+				}
+				err = fmt.Errorf("%w: term `%s` has no field", ErrFieldRequired, value)
+			}
+		case RangeQuery:
+			if v.Term == "" {
+				err = fmt.Errorf("%w: range `[%v TO %v]` has no field", ErrFieldRequired, v.Min, v.Max)
+			}
+		}
+		return err == nil
+	})
+	return err
+}
+
+// ErrValueTooLong is returned for values longer than the WithMaxValueLength limit
+var ErrValueTooLong = errors.New("value too long")
+
+// WithMaxValueLength rejects queries with a string value, wildcard pattern or range bound longer than
+// the given number of characters e.g. to bound the LIKE patterns of public search endpoints
+func WithMaxValueLength(length int) Option {
+	return GlobalStore("maxValueLength", length)
+}
+
+// maxValueLength returns an error for the first value of the node longer than the limit when set
+func maxValueLength(c *current, node interface{}) error {
+	limit, _ := c.globalStore["maxValueLength"].(int)
+	if limit <= 0 {
+		return nil
+	}
+	var err error
+	check := func(field string, value interface{}) {
+		var values []string
+		switch v := value.(type) {
+		case string:
+			values = []string{v}
+		case WildCardQuery:
+			values = []string{v.Prefix + v.Term + v.Suffix}
+		case []interface{}:
+			for _, e := range v {
+				if s, ok := e.(string); ok {
+					values = append(values, s)
+				}
+			}
+		}
+		for _, s := range values {
+			if n := utf8.RuneCountInString(s); err == nil && n > limit {
+				err = fmt.Errorf("%w: value of `%s` has %d characters, limit is %d", ErrValueTooLong, field, n, limit)
+			}
+		}
+	}
+	Walk(node, func(n interface{}) bool {
+		switch v := n.(type) {
+		case TermQuery:
+			check(v.Term, v.Value)
+		case RangeQuery:
+			check(v.Term, v.Min)
+			check(v.Term, v.Max)
+		}
+		return err == nil
+	})
+	return err
+}
+
+// ErrTooManyFields is returned for queries referencing more fields than the WithMaxFields limit
+var ErrTooManyFields = errors.New("too many fields")
+
+// WithMaxFields rejects queries referencing more than the given number of distinct field names
+// e.g. to cap the complexity of the queries of public search endpoints. Terms without a field are not counted
+func WithMaxFields(limit int) Option {
+	return GlobalStore("maxFields", limit)
+}
+
+// maxFields returns an error naming the first field of the node over the limit when set
+func maxFields(c *current, node interface{}) error {
+	limit, _ := c.globalStore["maxFields"].(int)
+	if limit <= 0 {
+		return nil
+	}
+	var err error
+	fields := map[string]bool{}
+	Walk(node, func(n interface{}) bool {
+		var field string
+		switch v := n.(type) {
+		case TermQuery:
+			field = v.Term
+		case RangeQuery:
+			field = v.Term
+		}
+		if field != "" && !fields[field] {
+			fields[field] = true
+			if len(fields) > limit {
+				err = fmt.Errorf("%w: field `%s` exceeds the limit of %d fields", ErrTooManyFields, field, limit)
+			}
+		}
+		return err == nil
+	})
+	return err
+}
+
+// WithDecimalComma parses numbers using `,` as the decimal separator e.g. `price: 23,5` is 23.5.
+// Array values are not affected as the comma separates the values
+func WithDecimalComma() Option {
+	return GlobalStore("decimalComma", true)
+}
+
+// decimalComma returns true when numbers use `,` as the decimal separator
+func decimalComma(c *current) bool {
+	enabled, _ := c.globalStore["decimalComma"].(bool)
+	return enabled
+}
+
+// coerceValue converts the value of a type annotated term (`zip:string:02134`) to the named type,
+// floats use `,` as the decimal separator when decimal is set
+func coerceValue(kind, value string, decimal bool) (interface{}, error) {
+	switch kind {
+	case "int":
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid int value `%s`", value)
+		}
+		return v, nil
+	case "float":
+		if decimal {
+			value = strings.Replace(value, ",", ".", 1)
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid float value `%s`", value)
+		}
+		return v, nil
+	case "bool":
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bool value `%s`", value)
+		}
+		return v, nil
+	}
+	return value, nil
+}
+
 func updateFieldName(v interface{}, name string) interface{} {
 	if list, ok := v.([]interface{}); ok {
 		arr := []interface{}{}
@@ -59,6 +273,11 @@ func updateFieldName(v interface{}, name string) interface{} {
 			t.Term = name
 		}
 		return t
+	case RangeQuery:
+		if t.Term == "" {
+			t.Term = name
+		}
+		return t
 	case BooleanExpression:
 		t.Args = updateFieldName(t.Args, name).([]interface{})
 		return t
@@ -69,6 +288,165 @@ func updateFieldName(v interface{}, name string) interface{} {
 	return v
 }
 
+// CastTypes are the types a field can be cast to in a query e.g. `id::int: "5"`, the cast is
+// rendered in the generated SQL so only types which are safe to compare with any column are listed
+var CastTypes = map[string]bool{
+	"int":         true,
+	"integer":     true,
+	"bigint":      true,
+	"smallint":    true,
+	"numeric":     true,
+	"real":        true,
+	"text":        true,
+	"boolean":     true,
+	"date":        true,
+	"timestamp":   true,
+	"timestamptz": true,
+	"uuid":        true,
+	"jsonb":       true,
+}
+
+// ErrInvalidCast is returned for a `::type` cast of a field to a type missing from CastTypes
+var ErrInvalidCast = errors.New("invalid cast")
+
+// castSeparator separates the field name from its cast until castFields sets the Cast of the terms
+const castSeparator = "\x00"
+
+// castFields moves the casts of the field names of the node to the Cast of its terms and ranges
+func castFields(v interface{}) interface{} {
+	if list, ok := v.([]interface{}); ok {
+		arr := []interface{}{}
+		for _, row := range list {
+			arr = append(arr, castFields(row))
+		}
+		return arr
+	}
+	split := func(field string) (string, string) {
+		if i := strings.Index(field, castSeparator); i >= 0 {
+			return field[:i], field[i+len(castSeparator):]
+		}
+		return field, ""
+	}
+	switch t := v.(type) {
+	case TermQuery:
+		t.Term, t.Cast = split(t.Term)
+		return t
+	case RangeQuery:
+		t.Term, t.Cast = split(t.Term)
+		return t
+	case BooleanExpression:
+		t.Args = castFields(t.Args).([]interface{})
+		return t
+	case *BooleanExpression:
+		t.Args = castFields(t.Args).([]interface{})
+		return t
+	}
+	return v
+}
+
+// combineRanges returns the single range of a field group of a lower and an upper bound comparison
+// e.g. `price:(> 10 < 100)` is the range `price:{10 TO 100}`. Comparisons which differ in inclusiveness
+// cannot be a single range and are joined with AND instead e.g. `price:(>= 10 < 100)`
+func combineRanges(v interface{}) interface{} {
+	b, ok := v.(BooleanExpression)
+	if !ok || (b.Op != "IMPLICIT" && b.Op != "AND") || len(b.Args) != 2 {
+		return v
+	}
+	lower, ok := b.Args[0].(RangeQuery)
+	upper, ok2 := b.Args[1].(RangeQuery)
+	if !ok || !ok2 {
+		return v
+	}
+	if !lower.HasMin() {
+		lower, upper = upper, lower
+	}
+	if !lower.HasMin() || lower.HasMax() || !upper.HasMax() || upper.HasMin() || lower.Term != upper.Term {
+		return v
+	}
+	if lower.Inclusive != upper.Inclusive {
+		return BooleanExpression{Op: "AND", Args: []interface{}{lower, upper}}
+	}
+	return RangeQuery{Term: lower.Term, Min: lower.Min, Max: upper.Max, Inclusive: lower.Inclusive}
+}
+
+// negate returns the negated node, terms are negated with the `-` prefix and other
+// nodes are wrapped in a NOT expression with a single argument
+func negate(v interface{}) interface{} {
+	switch t := v.(type) {
+	case TermQuery:
+		if t.Prefix == "-" {
+			t.Prefix = ""
+		} else {
+			t.Prefix = "-"
+		}
+		return t
+	case BooleanExpression:
+		if t.Op == "NOT" && len(t.Args) == 1 {
+			return t.Args[0]
+		}
+	}
+	return BooleanExpression{Op: "NOT", Args: []interface{}{v}}
+}
+
+// dotRange returns the query for a `min..max` range, a missing bound is unbounded. Bounds with
+// different inclusiveness are returned as an AND of the lower and upper bound ranges
+func dotRange(minInclusive bool, min interface{}, maxInclusive bool, max interface{}) interface{} {
+	if min == nil {
+		return RangeQuery{Min: "*", Max: max, Inclusive: maxInclusive}
+	}
+	if max == nil {
+		return RangeQuery{Min: min, Max: "*", Inclusive: minInclusive}
+	}
+	if minInclusive == maxInclusive {
+		return RangeQuery{Min: min, Max: max, Inclusive: minInclusive}
+	}
+	return BooleanExpression{
+		Op: "AND",
+		Args: []interface{}{
+			RangeQuery{Min: min, Max: "*", Inclusive: minInclusive},
+			RangeQuery{Min: "*", Max: max, Inclusive: maxInclusive},
+		},
+	}
+}
+
+// rangeAnchor is a time keyword used as a range bound, the text is kept in case
+// the range turns out not to be a time range
+type rangeAnchor struct {
+	anchor TimeAnchor
+	text   string
+}
+
+// dateLayouts are the layouts of a range bound that make the range a time range
+var dateLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// rangeBounds resolves the time keywords of a range, a keyword is only a time anchor when the other
+// bound is open, another keyword or a date e.g. `[yesterday TO *]`, otherwise it is the plain text
+// so `name: [now TO zebra]` stays a string range
+func rangeBounds(min, max interface{}) (interface{}, interface{}) {
+	return rangeBound(min, max), rangeBound(max, min)
+}
+
+func rangeBound(bound, other interface{}) interface{} {
+	a, ok := bound.(rangeAnchor)
+	if !ok {
+		return bound
+	}
+	switch v := other.(type) {
+	case rangeAnchor, time.Time:
+		return a.anchor
+	case string:
+		if v == "*" {
+			return a.anchor
+		}
+		for _, layout := range dateLayouts {
+			if _, err := time.Parse(layout, v); err == nil {
+				return a.anchor
+			}
+		}
+	}
+	return a.text
+}
+
 // WildCardQuery is a wildcard query term *
 type WildCardQuery struct {
 	Prefix string `json:"prefix,omitempty"`
@@ -90,12 +468,13 @@ func (q *WildCardQuery) Kind() string {
 	return "wildcard"
 }
 
-//RangeQuery is a query for a value range
+// RangeQuery is a query for a value range
 type RangeQuery struct {
 	Min       interface{} `json:"min,omitempty"`
 	Max       interface{} `json:"max,omitempty"`
 	Term      string      `json:"term,omitempty"`
 	Inclusive bool        `json:"inclusive"`
+	Cast      string      `json:"cast,omitempty"`
 }
 
 // HasMin returns true if the range has a minimum set
@@ -131,6 +510,7 @@ type TermQuery struct {
 	Prefix string      `json:"prefix,omitempty"`
 	Op     string      `json:"op,omitempty"`
 	Value  interface{} `json:"value,omitempty"`
+	Cast   string      `json:"cast,omitempty"`
 }
 
 // Query returns the effective query for this term query
@@ -139,6 +519,7 @@ func (t *TermQuery) Query() interface{} {
 	case "gt":
 		return RangeQuery{
 			Term:      t.Term,
+			Cast:      t.Cast,
 			Min:       t.Value,
 			Max:       "*",
 			Inclusive: false,
@@ -146,6 +527,7 @@ func (t *TermQuery) Query() interface{} {
 	case "gte":
 		return RangeQuery{
 			Term:      t.Term,
+			Cast:      t.Cast,
 			Min:       t.Value,
 			Max:       "*",
 			Inclusive: true,
@@ -153,6 +535,7 @@ func (t *TermQuery) Query() interface{} {
 	case "lt":
 		return RangeQuery{
 			Term:      t.Term,
+			Cast:      t.Cast,
 			Min:       "*",
 			Max:       t.Value,
 			Inclusive: false,
@@ -160,6 +543,7 @@ func (t *TermQuery) Query() interface{} {
 	case "lte":
 		return RangeQuery{
 			Term:      t.Term,
+			Cast:      t.Cast,
 			Min:       "*",
 			Max:       t.Value,
 			Inclusive: true,
@@ -175,34 +559,66 @@ type BooleanExpression struct {
 	Args []interface{} `json:"args,omitempty"`
 }
 
+// TimeAnchor is a relative time keyword (today, yesterday, now) compared with a field,
+// it is resolved to a time when the query is used e.g. `created: >= yesterday`
+type TimeAnchor string
+
+// Time returns the time of the anchor relative to now, today and yesterday are the start of the day
+func (a TimeAnchor) Time(now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch a {
+	case "today":
+		return today
+	case "yesterday":
+		return today.AddDate(0, 0, -1)
+	}
+	return now
+}
+
 var g = &grammar{
 	rules: []*rule{
 		{
 			name: "Start",
-			pos:  position{line: 217, col: 1, offset: 5857},
+			pos:  position{line: 636, col: 1, offset: 21556},
 			expr: &choiceExpr{
-				pos: position{line: 218, col: 5, offset: 5867},
+				pos: position{line: 637, col: 5, offset: 21566},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 218, col: 5, offset: 5867},
+						pos: position{line: 637, col: 5, offset: 21566},
 						run: (*parser).callonStart2,
 						expr: &seqExpr{
-							pos: position{line: 218, col: 5, offset: 5867},
+							pos: position{line: 637, col: 5, offset: 21566},
 							exprs: []interface{}{
+								&zeroOrOneExpr{
+									pos: position{line: 637, col: 5, offset: 21566},
+									expr: &litMatcher{
+										pos:        position{line: 637, col: 5, offset: 21566},
+										val:        "\ufeff",
+										ignoreCase: false,
+										want:       "\"\\ufeff\"",
+									},
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 637, col: 15, offset: 21576},
+									expr: &ruleRefExpr{
+										pos:  position{line: 637, col: 15, offset: 21576},
+										name: "LineComment",
+									},
+								},
 								&zeroOrMoreExpr{
-									pos: position{line: 218, col: 5, offset: 5867},
+									pos: position{line: 637, col: 28, offset: 21589},
 									expr: &ruleRefExpr{
-										pos:  position{line: 218, col: 5, offset: 5867},
+										pos:  position{line: 637, col: 28, offset: 21589},
 										name: "_",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 218, col: 8, offset: 5870},
+									pos:   position{line: 637, col: 31, offset: 21592},
 									label: "node",
 									expr: &oneOrMoreExpr{
-										pos: position{line: 218, col: 13, offset: 5875},
+										pos: position{line: 637, col: 36, offset: 21597},
 										expr: &ruleRefExpr{
-											pos:  position{line: 218, col: 13, offset: 5875},
+											pos:  position{line: 637, col: 36, offset: 21597},
 											name: "Node",
 										},
 									},
@@ -211,21 +627,21 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 222, col: 5, offset: 5949},
-						run: (*parser).callonStart9,
+						pos: position{line: 654, col: 5, offset: 22093},
+						run: (*parser).callonStart13,
 						expr: &zeroOrMoreExpr{
-							pos: position{line: 222, col: 5, offset: 5949},
+							pos: position{line: 654, col: 5, offset: 22093},
 							expr: &ruleRefExpr{
-								pos:  position{line: 222, col: 5, offset: 5949},
+								pos:  position{line: 654, col: 5, offset: 22093},
 								name: "_",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 226, col: 5, offset: 6016},
-						run: (*parser).callonStart12,
+						pos: position{line: 658, col: 5, offset: 22160},
+						run: (*parser).callonStart16,
 						expr: &ruleRefExpr{
-							pos:  position{line: 226, col: 5, offset: 6016},
+							pos:  position{line: 658, col: 5, offset: 22160},
 							name: "EOF",
 						},
 					},
@@ -234,50 +650,57 @@ var g = &grammar{
 		},
 		{
 			name: "Node",
-			pos:  position{line: 231, col: 1, offset: 6081},
+			pos:  position{line: 663, col: 1, offset: 22225},
 			expr: &choiceExpr{
-				pos: position{line: 232, col: 5, offset: 6090},
+				pos: position{line: 664, col: 5, offset: 22234},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 232, col: 5, offset: 6090},
+						pos: position{line: 664, col: 5, offset: 22234},
 						run: (*parser).callonNode2,
 						expr: &seqExpr{
-							pos: position{line: 232, col: 5, offset: 6090},
+							pos: position{line: 664, col: 5, offset: 22234},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 232, col: 5, offset: 6090},
+									pos:   position{line: 664, col: 5, offset: 22234},
 									label: "operator",
 									expr: &ruleRefExpr{
-										pos:  position{line: 232, col: 14, offset: 6099},
+										pos:  position{line: 664, col: 14, offset: 22243},
 										name: "OperatorExp",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 232, col: 26, offset: 6111},
+									pos:  position{line: 664, col: 26, offset: 22255},
 									name: "EOF",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 238, col: 5, offset: 6216},
+						pos: position{line: 670, col: 5, offset: 22360},
 						run: (*parser).callonNode7,
 						expr: &seqExpr{
-							pos: position{line: 238, col: 5, offset: 6216},
+							pos: position{line: 670, col: 5, offset: 22360},
 							exprs: []interface{}{
+								&notExpr{
+									pos: position{line: 670, col: 5, offset: 22360},
+									expr: &ruleRefExpr{
+										pos:  position{line: 670, col: 6, offset: 22361},
+										name: "NotOperatorExp",
+									},
+								},
 								&labeledExpr{
-									pos:   position{line: 238, col: 5, offset: 6216},
+									pos:   position{line: 670, col: 21, offset: 22376},
 									label: "operator",
 									expr: &ruleRefExpr{
-										pos:  position{line: 238, col: 14, offset: 6225},
+										pos:  position{line: 670, col: 30, offset: 22385},
 										name: "OperatorExp",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 238, col: 26, offset: 6237},
+									pos:   position{line: 670, col: 42, offset: 22397},
 									label: "right",
 									expr: &ruleRefExpr{
-										pos:  position{line: 238, col: 32, offset: 6243},
+										pos:  position{line: 670, col: 48, offset: 22403},
 										name: "Node",
 									},
 								},
@@ -285,37 +708,37 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 242, col: 4, offset: 6289},
-						run: (*parser).callonNode13,
+						pos: position{line: 674, col: 4, offset: 22449},
+						run: (*parser).callonNode15,
 						expr: &seqExpr{
-							pos: position{line: 242, col: 4, offset: 6289},
+							pos: position{line: 674, col: 4, offset: 22449},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 242, col: 4, offset: 6289},
+									pos:   position{line: 674, col: 4, offset: 22449},
 									label: "left",
 									expr: &ruleRefExpr{
-										pos:  position{line: 242, col: 9, offset: 6294},
+										pos:  position{line: 674, col: 9, offset: 22454},
 										name: "GroupExp",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 242, col: 18, offset: 6303},
+									pos:   position{line: 674, col: 18, offset: 22463},
 									label: "op",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 242, col: 21, offset: 6306},
+										pos: position{line: 674, col: 21, offset: 22466},
 										expr: &ruleRefExpr{
-											pos:  position{line: 242, col: 21, offset: 6306},
+											pos:  position{line: 674, col: 21, offset: 22466},
 											name: "OperatorExp",
 										},
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 242, col: 34, offset: 6319},
+									pos:   position{line: 674, col: 34, offset: 22479},
 									label: "right",
 									expr: &oneOrMoreExpr{
-										pos: position{line: 242, col: 40, offset: 6325},
+										pos: position{line: 674, col: 40, offset: 22485},
 										expr: &ruleRefExpr{
-											pos:  position{line: 242, col: 40, offset: 6325},
+											pos:  position{line: 674, col: 40, offset: 22485},
 											name: "Node",
 										},
 									},
@@ -324,13 +747,13 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 268, col: 4, offset: 6967},
-						run: (*parser).callonNode23,
+						pos: position{line: 700, col: 4, offset: 23127},
+						run: (*parser).callonNode25,
 						expr: &labeledExpr{
-							pos:   position{line: 268, col: 4, offset: 6967},
+							pos:   position{line: 700, col: 4, offset: 23127},
 							label: "ex",
 							expr: &ruleRefExpr{
-								pos:  position{line: 268, col: 7, offset: 6970},
+								pos:  position{line: 700, col: 7, offset: 23130},
 								name: "GroupExp",
 							},
 						},
@@ -340,28 +763,124 @@ var g = &grammar{
 		},
 		{
 			name: "GroupExp",
-			pos:  position{line: 273, col: 1, offset: 7014},
+			pos:  position{line: 705, col: 1, offset: 23174},
 			expr: &choiceExpr{
-				pos: position{line: 274, col: 5, offset: 7027},
+				pos: position{line: 706, col: 5, offset: 23187},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 274, col: 5, offset: 7027},
+						pos: position{line: 706, col: 5, offset: 23187},
 						run: (*parser).callonGroupExp2,
 						expr: &seqExpr{
-							pos: position{line: 274, col: 5, offset: 7027},
+							pos: position{line: 706, col: 5, offset: 23187},
+							exprs: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 706, col: 5, offset: 23187},
+									name: "NotOperatorExp",
+								},
+								&labeledExpr{
+									pos:   position{line: 706, col: 20, offset: 23202},
+									label: "exp",
+									expr: &ruleRefExpr{
+										pos:  position{line: 706, col: 24, offset: 23206},
+										name: "GroupExp",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 710, col: 5, offset: 23263},
+						run: (*parser).callonGroupExp7,
+						expr: &seqExpr{
+							pos: position{line: 710, col: 5, offset: 23263},
+							exprs: []interface{}{
+								&labeledExpr{
+									pos:   position{line: 710, col: 5, offset: 23263},
+									label: "prefix",
+									expr: &ruleRefExpr{
+										pos:  position{line: 710, col: 12, offset: 23270},
+										name: "PrefixOperatorExp",
+									},
+								},
+								&andExpr{
+									pos: position{line: 710, col: 30, offset: 23288},
+									expr: &choiceExpr{
+										pos: position{line: 710, col: 32, offset: 23290},
+										alternatives: []interface{}{
+											&ruleRefExpr{
+												pos:  position{line: 710, col: 32, offset: 23290},
+												name: "Fieldname",
+											},
+											&seqExpr{
+												pos: position{line: 710, col: 44, offset: 23302},
+												exprs: []interface{}{
+													&choiceExpr{
+														pos: position{line: 710, col: 45, offset: 23303},
+														alternatives: []interface{}{
+															&ruleRefExpr{
+																pos:  position{line: 710, col: 45, offset: 23303},
+																name: "QuotedTerm",
+															},
+															&ruleRefExpr{
+																pos:  position{line: 710, col: 58, offset: 23316},
+																name: "UnquotedTerm",
+															},
+														},
+													},
+													&zeroOrMoreExpr{
+														pos: position{line: 710, col: 72, offset: 23330},
+														expr: &ruleRefExpr{
+															pos:  position{line: 710, col: 72, offset: 23330},
+															name: "_",
+														},
+													},
+													&litMatcher{
+														pos:        position{line: 710, col: 75, offset: 23333},
+														val:        "??",
+														ignoreCase: false,
+														want:       "\"??\"",
+													},
+												},
+											},
+										},
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 710, col: 81, offset: 23339},
+									label: "exp",
+									expr: &ruleRefExpr{
+										pos:  position{line: 710, col: 85, offset: 23343},
+										name: "FieldExp",
+									},
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 710, col: 94, offset: 23352},
+									expr: &ruleRefExpr{
+										pos:  position{line: 710, col: 94, offset: 23352},
+										name: "_",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 721, col: 5, offset: 23585},
+						run: (*parser).callonGroupExp25,
+						expr: &seqExpr{
+							pos: position{line: 721, col: 5, offset: 23585},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 274, col: 5, offset: 7027},
+									pos:   position{line: 721, col: 5, offset: 23585},
 									label: "exp",
 									expr: &ruleRefExpr{
-										pos:  position{line: 274, col: 9, offset: 7031},
+										pos:  position{line: 721, col: 9, offset: 23589},
 										name: "FieldExp",
 									},
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 274, col: 18, offset: 7040},
+									pos: position{line: 721, col: 18, offset: 23598},
 									expr: &ruleRefExpr{
-										pos:  position{line: 274, col: 18, offset: 7040},
+										pos:  position{line: 721, col: 18, offset: 23598},
 										name: "_",
 									},
 								},
@@ -369,48 +888,88 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 278, col: 5, offset: 7083},
+						pos:  position{line: 725, col: 5, offset: 23641},
 						name: "ParenExp",
 					},
 				},
 			},
 		},
+		{
+			name: "NotOperatorExp",
+			pos:  position{line: 727, col: 1, offset: 23651},
+			expr: &seqExpr{
+				pos: position{line: 728, col: 5, offset: 23670},
+				exprs: []interface{}{
+					&zeroOrMoreExpr{
+						pos: position{line: 728, col: 5, offset: 23670},
+						expr: &ruleRefExpr{
+							pos:  position{line: 728, col: 5, offset: 23670},
+							name: "_",
+						},
+					},
+					&choiceExpr{
+						pos: position{line: 728, col: 9, offset: 23674},
+						alternatives: []interface{}{
+							&litMatcher{
+								pos:        position{line: 728, col: 9, offset: 23674},
+								val:        "NOT",
+								ignoreCase: false,
+								want:       "\"NOT\"",
+							},
+							&litMatcher{
+								pos:        position{line: 728, col: 17, offset: 23682},
+								val:        "not",
+								ignoreCase: false,
+								want:       "\"not\"",
+							},
+						},
+					},
+					&oneOrMoreExpr{
+						pos: position{line: 728, col: 24, offset: 23689},
+						expr: &ruleRefExpr{
+							pos:  position{line: 728, col: 24, offset: 23689},
+							name: "_",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "ParenExp",
-			pos:  position{line: 280, col: 1, offset: 7093},
+			pos:  position{line: 730, col: 1, offset: 23693},
 			expr: &actionExpr{
-				pos: position{line: 281, col: 5, offset: 7106},
+				pos: position{line: 731, col: 5, offset: 23706},
 				run: (*parser).callonParenExp1,
 				expr: &seqExpr{
-					pos: position{line: 281, col: 5, offset: 7106},
+					pos: position{line: 731, col: 5, offset: 23706},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 281, col: 5, offset: 7106},
+							pos:        position{line: 731, col: 5, offset: 23706},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 281, col: 9, offset: 7110},
+							pos:   position{line: 731, col: 9, offset: 23710},
 							label: "node",
 							expr: &oneOrMoreExpr{
-								pos: position{line: 281, col: 14, offset: 7115},
+								pos: position{line: 731, col: 14, offset: 23715},
 								expr: &ruleRefExpr{
-									pos:  position{line: 281, col: 14, offset: 7115},
+									pos:  position{line: 731, col: 14, offset: 23715},
 									name: "Node",
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 281, col: 20, offset: 7121},
+							pos:        position{line: 731, col: 20, offset: 23721},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 281, col: 24, offset: 7125},
+							pos: position{line: 731, col: 24, offset: 23725},
 							expr: &ruleRefExpr{
-								pos:  position{line: 281, col: 24, offset: 7125},
+								pos:  position{line: 731, col: 24, offset: 23725},
 								name: "_",
 							},
 						},
@@ -420,39 +979,64 @@ var g = &grammar{
 		},
 		{
 			name: "FieldExp",
-			pos:  position{line: 289, col: 1, offset: 7267},
+			pos:  position{line: 739, col: 1, offset: 23867},
 			expr: &choiceExpr{
-				pos: position{line: 290, col: 5, offset: 7280},
+				pos: position{line: 740, col: 5, offset: 23880},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 290, col: 5, offset: 7280},
+						pos: position{line: 740, col: 5, offset: 23880},
 						run: (*parser).callonFieldExp2,
 						expr: &seqExpr{
-							pos: position{line: 290, col: 5, offset: 7280},
+							pos: position{line: 740, col: 5, offset: 23880},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 290, col: 5, offset: 7280},
+									pos:   position{line: 740, col: 5, offset: 23880},
 									label: "fieldname",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 290, col: 15, offset: 7290},
+										pos: position{line: 740, col: 15, offset: 23890},
 										expr: &ruleRefExpr{
-											pos:  position{line: 290, col: 15, offset: 7290},
+											pos:  position{line: 740, col: 15, offset: 23890},
 											name: "Fieldname",
 										},
 									},
 								},
+								&ruleRefExpr{
+									pos:  position{line: 740, col: 26, offset: 23901},
+									name: "ValueSpace",
+								},
+								&labeledExpr{
+									pos:   position{line: 740, col: 37, offset: 23912},
+									label: "quantifier",
+									expr: &choiceExpr{
+										pos: position{line: 740, col: 49, offset: 23924},
+										alternatives: []interface{}{
+											&litMatcher{
+												pos:        position{line: 740, col: 49, offset: 23924},
+												val:        "all",
+												ignoreCase: true,
+												want:       "\"all\"i",
+											},
+											&litMatcher{
+												pos:        position{line: 740, col: 58, offset: 23933},
+												val:        "any",
+												ignoreCase: true,
+												want:       "\"any\"i",
+											},
+										},
+									},
+								},
 								&zeroOrMoreExpr{
-									pos: position{line: 290, col: 26, offset: 7301},
+									pos: position{line: 740, col: 66, offset: 23941},
 									expr: &ruleRefExpr{
-										pos:  position{line: 290, col: 26, offset: 7301},
+										pos:  position{line: 740, col: 66, offset: 23941},
 										name: "_",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 290, col: 29, offset: 7304},
+									pos:   position{line: 740, col: 69, offset: 23944},
 									label: "arr",
 									expr: &ruleRefExpr{
-										pos:  position{line: 290, col: 33, offset: 7308},
+										pos:  position{line: 740, col: 73, offset: 23948},
 										name: "ArrayExp",
 									},
 								},
@@ -460,142 +1044,492 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 299, col: 5, offset: 7486},
-						run: (*parser).callonFieldExp11,
+						pos: position{line: 749, col: 5, offset: 24161},
+						run: (*parser).callonFieldExp16,
 						expr: &seqExpr{
-							pos: position{line: 299, col: 5, offset: 7486},
+							pos: position{line: 749, col: 5, offset: 24161},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 299, col: 5, offset: 7486},
+									pos:   position{line: 749, col: 5, offset: 24161},
 									label: "fieldname",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 299, col: 15, offset: 7496},
+										pos: position{line: 749, col: 15, offset: 24171},
 										expr: &ruleRefExpr{
-											pos:  position{line: 299, col: 15, offset: 7496},
+											pos:  position{line: 749, col: 15, offset: 24171},
 											name: "Fieldname",
 										},
 									},
 								},
-								&zeroOrMoreExpr{
-									pos: position{line: 299, col: 26, offset: 7507},
-									expr: &ruleRefExpr{
-										pos:  position{line: 299, col: 26, offset: 7507},
-										name: "_",
-									},
+								&ruleRefExpr{
+									pos:  position{line: 749, col: 26, offset: 24182},
+									name: "ValueSpace",
 								},
 								&labeledExpr{
-									pos:   position{line: 299, col: 29, offset: 7510},
-									label: "rangeValue",
+									pos:   position{line: 749, col: 37, offset: 24193},
+									label: "arr",
 									expr: &ruleRefExpr{
-										pos:  position{line: 299, col: 40, offset: 7521},
-										name: "RangeOperatorExp",
+										pos:  position{line: 749, col: 41, offset: 24197},
+										name: "ArrayExp",
 									},
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 308, col: 5, offset: 7735},
-						run: (*parser).callonFieldExp20,
+						pos: position{line: 758, col: 5, offset: 24375},
+						run: (*parser).callonFieldExp24,
 						expr: &seqExpr{
-							pos: position{line: 308, col: 5, offset: 7735},
+							pos: position{line: 758, col: 5, offset: 24375},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 308, col: 5, offset: 7735},
+									pos:   position{line: 758, col: 5, offset: 24375},
 									label: "fieldname",
-									expr: &ruleRefExpr{
-										pos:  position{line: 308, col: 15, offset: 7745},
-										name: "Fieldname",
+									expr: &zeroOrOneExpr{
+										pos: position{line: 758, col: 15, offset: 24385},
+										expr: &ruleRefExpr{
+											pos:  position{line: 758, col: 15, offset: 24385},
+											name: "Fieldname",
+										},
 									},
 								},
-								&zeroOrMoreExpr{
-									pos: position{line: 308, col: 25, offset: 7755},
-									expr: &ruleRefExpr{
-										pos:  position{line: 308, col: 25, offset: 7755},
-										name: "_",
-									},
+								&ruleRefExpr{
+									pos:  position{line: 758, col: 26, offset: 24396},
+									name: "ValueSpace",
 								},
 								&labeledExpr{
-									pos:   position{line: 308, col: 28, offset: 7758},
-									label: "node",
+									pos:   position{line: 758, col: 37, offset: 24407},
+									label: "rangeValue",
 									expr: &ruleRefExpr{
-										pos:  position{line: 308, col: 33, offset: 7763},
-										name: "ParenExp",
+										pos:  position{line: 758, col: 48, offset: 24418},
+										name: "RangeOperatorExp",
 									},
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 317, col: 5, offset: 7990},
-						run: (*parser).callonFieldExp28,
+						pos: position{line: 767, col: 5, offset: 24632},
+						run: (*parser).callonFieldExp32,
 						expr: &seqExpr{
-							pos: position{line: 317, col: 5, offset: 7990},
+							pos: position{line: 767, col: 5, offset: 24632},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 317, col: 5, offset: 7990},
+									pos:   position{line: 767, col: 5, offset: 24632},
 									label: "fieldname",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 317, col: 15, offset: 8000},
+										pos: position{line: 767, col: 15, offset: 24642},
 										expr: &ruleRefExpr{
-											pos:  position{line: 317, col: 15, offset: 8000},
+											pos:  position{line: 767, col: 15, offset: 24642},
 											name: "Fieldname",
 										},
 									},
 								},
-								&zeroOrMoreExpr{
-									pos: position{line: 317, col: 26, offset: 8011},
-									expr: &ruleRefExpr{
-										pos:  position{line: 317, col: 26, offset: 8011},
-										name: "_",
-									},
+								&ruleRefExpr{
+									pos:  position{line: 767, col: 26, offset: 24653},
+									name: "ValueSpace",
 								},
 								&labeledExpr{
-									pos:   position{line: 317, col: 29, offset: 8014},
-									label: "term",
+									pos:   position{line: 767, col: 37, offset: 24664},
+									label: "rangeValue",
 									expr: &ruleRefExpr{
-										pos:  position{line: 317, col: 34, offset: 8019},
-										name: "Term",
+										pos:  position{line: 767, col: 48, offset: 24675},
+										name: "DotRangeExp",
 									},
 								},
 							},
 						},
 					},
-				},
+					&actionExpr{
+						pos: position{line: 771, col: 5, offset: 24774},
+						run: (*parser).callonFieldExp40,
+						expr: &seqExpr{
+							pos: position{line: 771, col: 5, offset: 24774},
+							exprs: []interface{}{
+								&labeledExpr{
+									pos:   position{line: 771, col: 5, offset: 24774},
+									label: "fieldname",
+									expr: &ruleRefExpr{
+										pos:  position{line: 771, col: 15, offset: 24784},
+										name: "Fieldname",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 771, col: 25, offset: 24794},
+									name: "ValueSpace",
+								},
+								&labeledExpr{
+									pos:   position{line: 771, col: 36, offset: 24805},
+									label: "node",
+									expr: &ruleRefExpr{
+										pos:  position{line: 771, col: 41, offset: 24810},
+										name: "ParenExp",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 780, col: 5, offset: 25052},
+						run: (*parser).callonFieldExp47,
+						expr: &seqExpr{
+							pos: position{line: 780, col: 5, offset: 25052},
+							exprs: []interface{}{
+								&labeledExpr{
+									pos:   position{line: 780, col: 5, offset: 25052},
+									label: "fieldname",
+									expr: &ruleRefExpr{
+										pos:  position{line: 780, col: 15, offset: 25062},
+										name: "Fieldname",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 780, col: 25, offset: 25072},
+									name: "ValueSpace",
+								},
+								&labeledExpr{
+									pos:   position{line: 780, col: 36, offset: 25083},
+									label: "kind",
+									expr: &ruleRefExpr{
+										pos:  position{line: 780, col: 41, offset: 25088},
+										name: "TypeAnnotation",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 780, col: 56, offset: 25103},
+									label: "eq",
+									expr: &zeroOrOneExpr{
+										pos: position{line: 780, col: 59, offset: 25106},
+										expr: &ruleRefExpr{
+											pos:  position{line: 780, col: 59, offset: 25106},
+											name: "EqualityExpr",
+										},
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 780, col: 73, offset: 25120},
+									label: "value",
+									expr: &ruleRefExpr{
+										pos:  position{line: 780, col: 79, offset: 25126},
+										name: "TypedValue",
+									},
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 780, col: 90, offset: 25137},
+									expr: &ruleRefExpr{
+										pos:  position{line: 780, col: 90, offset: 25137},
+										name: "_",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 793, col: 5, offset: 25461},
+						run: (*parser).callonFieldExp61,
+						expr: &seqExpr{
+							pos: position{line: 793, col: 5, offset: 25461},
+							exprs: []interface{}{
+								&labeledExpr{
+									pos:   position{line: 793, col: 5, offset: 25461},
+									label: "fieldname",
+									expr: &choiceExpr{
+										pos: position{line: 793, col: 16, offset: 25472},
+										alternatives: []interface{}{
+											&ruleRefExpr{
+												pos:  position{line: 793, col: 16, offset: 25472},
+												name: "QuotedTerm",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 793, col: 29, offset: 25485},
+												name: "UnquotedTerm",
+											},
+										},
+									},
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 793, col: 43, offset: 25499},
+									expr: &ruleRefExpr{
+										pos:  position{line: 793, col: 43, offset: 25499},
+										name: "_",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 793, col: 46, offset: 25502},
+									val:        "??",
+									ignoreCase: false,
+									want:       "\"??\"",
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 793, col: 51, offset: 25507},
+									expr: &ruleRefExpr{
+										pos:  position{line: 793, col: 51, offset: 25507},
+										name: "_",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 793, col: 54, offset: 25510},
+									label: "term",
+									expr: &ruleRefExpr{
+										pos:  position{line: 793, col: 59, offset: 25515},
+										name: "Term",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 800, col: 5, offset: 25643},
+						run: (*parser).callonFieldExp74,
+						expr: &seqExpr{
+							pos: position{line: 800, col: 5, offset: 25643},
+							exprs: []interface{}{
+								&labeledExpr{
+									pos:   position{line: 800, col: 5, offset: 25643},
+									label: "fieldname",
+									expr: &ruleRefExpr{
+										pos:  position{line: 800, col: 15, offset: 25653},
+										name: "Fieldname",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 800, col: 25, offset: 25663},
+									name: "ValueSpace",
+								},
+								&labeledExpr{
+									pos:   position{line: 800, col: 36, offset: 25674},
+									label: "value",
+									expr: &ruleRefExpr{
+										pos:  position{line: 800, col: 42, offset: 25680},
+										name: "ColonTerm",
+									},
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 800, col: 52, offset: 25690},
+									expr: &ruleRefExpr{
+										pos:  position{line: 800, col: 52, offset: 25690},
+										name: "_",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 807, col: 5, offset: 25817},
+						run: (*parser).callonFieldExp83,
+						expr: &seqExpr{
+							pos: position{line: 807, col: 5, offset: 25817},
+							exprs: []interface{}{
+								&labeledExpr{
+									pos:   position{line: 807, col: 5, offset: 25817},
+									label: "fieldname",
+									expr: &zeroOrOneExpr{
+										pos: position{line: 807, col: 15, offset: 25827},
+										expr: &ruleRefExpr{
+											pos:  position{line: 807, col: 15, offset: 25827},
+											name: "Fieldname",
+										},
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 807, col: 26, offset: 25838},
+									name: "ValueSpace",
+								},
+								&labeledExpr{
+									pos:   position{line: 807, col: 37, offset: 25849},
+									label: "term",
+									expr: &ruleRefExpr{
+										pos:  position{line: 807, col: 42, offset: 25854},
+										name: "Term",
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 		},
 		{
 			name: "Fieldname",
-			pos:  position{line: 324, col: 1, offset: 8133},
+			pos:  position{line: 814, col: 1, offset: 25968},
+			expr: &choiceExpr{
+				pos: position{line: 815, col: 5, offset: 25982},
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 815, col: 5, offset: 25982},
+						run: (*parser).callonFieldname2,
+						expr: &seqExpr{
+							pos: position{line: 815, col: 5, offset: 25982},
+							exprs: []interface{}{
+								&labeledExpr{
+									pos:   position{line: 815, col: 5, offset: 25982},
+									label: "fieldname",
+									expr: &choiceExpr{
+										pos: position{line: 815, col: 16, offset: 25993},
+										alternatives: []interface{}{
+											&ruleRefExpr{
+												pos:  position{line: 815, col: 16, offset: 25993},
+												name: "UnquotedTerm",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 815, col: 31, offset: 26008},
+												name: "QuotedTerm",
+											},
+										},
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 815, col: 43, offset: 26020},
+									val:        "::",
+									ignoreCase: false,
+									want:       "\"::\"",
+								},
+								&labeledExpr{
+									pos:   position{line: 815, col: 48, offset: 26025},
+									label: "kind",
+									expr: &ruleRefExpr{
+										pos:  position{line: 815, col: 53, offset: 26030},
+										name: "CastType",
+									},
+								},
+								&charClassMatcher{
+									pos:        position{line: 815, col: 62, offset: 26039},
+									val:        "[:]",
+									chars:      []rune{':'},
+									ignoreCase: false,
+									inverted:   false,
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 823, col: 5, offset: 26328},
+						run: (*parser).callonFieldname12,
+						expr: &seqExpr{
+							pos: position{line: 823, col: 5, offset: 26328},
+							exprs: []interface{}{
+								&labeledExpr{
+									pos:   position{line: 823, col: 5, offset: 26328},
+									label: "fieldname",
+									expr: &choiceExpr{
+										pos: position{line: 823, col: 16, offset: 26339},
+										alternatives: []interface{}{
+											&ruleRefExpr{
+												pos:  position{line: 823, col: 16, offset: 26339},
+												name: "UnquotedTerm",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 823, col: 31, offset: 26354},
+												name: "QuotedTerm",
+											},
+										},
+									},
+								},
+								&charClassMatcher{
+									pos:        position{line: 823, col: 43, offset: 26366},
+									val:        "[:]",
+									chars:      []rune{':'},
+									ignoreCase: false,
+									inverted:   false,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "CastType",
+			pos:  position{line: 832, col: 1, offset: 26552},
+			expr: &actionExpr{
+				pos: position{line: 833, col: 5, offset: 26565},
+				run: (*parser).callonCastType1,
+				expr: &oneOrMoreExpr{
+					pos: position{line: 833, col: 5, offset: 26565},
+					expr: &charClassMatcher{
+						pos:        position{line: 833, col: 5, offset: 26565},
+						val:        "[a-zA-Z0-9_]",
+						chars:      []rune{'_'},
+						ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
+						ignoreCase: false,
+						inverted:   false,
+					},
+				},
+			},
+		},
+		{
+			name: "TypeAnnotation",
+			pos:  position{line: 838, col: 1, offset: 26644},
 			expr: &actionExpr{
-				pos: position{line: 325, col: 5, offset: 8147},
-				run: (*parser).callonFieldname1,
+				pos: position{line: 839, col: 5, offset: 26663},
+				run: (*parser).callonTypeAnnotation1,
 				expr: &seqExpr{
-					pos: position{line: 325, col: 5, offset: 8147},
+					pos: position{line: 839, col: 5, offset: 26663},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 325, col: 5, offset: 8147},
-							label: "fieldname",
+							pos:   position{line: 839, col: 5, offset: 26663},
+							label: "kind",
 							expr: &choiceExpr{
-								pos: position{line: 325, col: 16, offset: 8158},
+								pos: position{line: 839, col: 11, offset: 26669},
 								alternatives: []interface{}{
-									&ruleRefExpr{
-										pos:  position{line: 325, col: 16, offset: 8158},
-										name: "UnquotedTerm",
+									&litMatcher{
+										pos:        position{line: 839, col: 11, offset: 26669},
+										val:        "string",
+										ignoreCase: false,
+										want:       "\"string\"",
 									},
-									&ruleRefExpr{
-										pos:  position{line: 325, col: 31, offset: 8173},
-										name: "QuotedTerm",
+									&litMatcher{
+										pos:        position{line: 839, col: 22, offset: 26680},
+										val:        "int",
+										ignoreCase: false,
+										want:       "\"int\"",
+									},
+									&litMatcher{
+										pos:        position{line: 839, col: 30, offset: 26688},
+										val:        "float",
+										ignoreCase: false,
+										want:       "\"float\"",
+									},
+									&litMatcher{
+										pos:        position{line: 839, col: 40, offset: 26698},
+										val:        "bool",
+										ignoreCase: false,
+										want:       "\"bool\"",
 									},
 								},
 							},
 						},
-						&charClassMatcher{
-							pos:        position{line: 325, col: 43, offset: 8185},
-							val:        "[:]",
-							chars:      []rune{':'},
+						&litMatcher{
+							pos:        position{line: 839, col: 48, offset: 26706},
+							val:        ":",
 							ignoreCase: false,
-							inverted:   false,
+							want:       "\":\"",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "TypedValue",
+			pos:  position{line: 844, col: 1, offset: 26760},
+			expr: &choiceExpr{
+				pos: position{line: 845, col: 5, offset: 26775},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 845, col: 5, offset: 26775},
+						name: "QuotedTerm",
+					},
+					&actionExpr{
+						pos: position{line: 846, col: 5, offset: 26790},
+						run: (*parser).callonTypedValue3,
+						expr: &oneOrMoreExpr{
+							pos: position{line: 846, col: 5, offset: 26790},
+							expr: &charClassMatcher{
+								pos:        position{line: 846, col: 5, offset: 26790},
+								val:        "[^ \\t\\r\\n\\u00A0)(]",
+								chars:      []rune{' ', '\t', '\r', '\n', '\u00a0', ')', '('},
+								ignoreCase: false,
+								inverted:   true,
+							},
 						},
 					},
 				},
@@ -603,39 +1537,84 @@ var g = &grammar{
 		},
 		{
 			name: "Term",
-			pos:  position{line: 330, col: 1, offset: 8232},
+			pos:  position{line: 851, col: 1, offset: 26858},
 			expr: &choiceExpr{
-				pos: position{line: 331, col: 5, offset: 8241},
+				pos: position{line: 852, col: 5, offset: 26867},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 331, col: 5, offset: 8241},
+						pos: position{line: 852, col: 5, offset: 26867},
 						run: (*parser).callonTerm2,
 						expr: &seqExpr{
-							pos: position{line: 331, col: 5, offset: 8241},
+							pos: position{line: 852, col: 5, offset: 26867},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 331, col: 5, offset: 8241},
+									pos:   position{line: 852, col: 5, offset: 26867},
+									label: "eq",
+									expr: &ruleRefExpr{
+										pos:  position{line: 852, col: 8, offset: 26870},
+										name: "EqualityExpr",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 852, col: 21, offset: 26883},
+									label: "term",
+									expr: &ruleRefExpr{
+										pos:  position{line: 852, col: 26, offset: 26888},
+										name: "TimeAnchor",
+									},
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 852, col: 37, offset: 26899},
+									expr: &ruleRefExpr{
+										pos:  position{line: 852, col: 37, offset: 26899},
+										name: "_",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 859, col: 5, offset: 27016},
+						run: (*parser).callonTerm10,
+						expr: &seqExpr{
+							pos: position{line: 859, col: 5, offset: 27016},
+							exprs: []interface{}{
+								&labeledExpr{
+									pos:   position{line: 859, col: 5, offset: 27016},
 									label: "eq",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 331, col: 8, offset: 8244},
+										pos: position{line: 859, col: 8, offset: 27019},
 										expr: &ruleRefExpr{
-											pos:  position{line: 331, col: 8, offset: 8244},
+											pos:  position{line: 859, col: 8, offset: 27019},
 											name: "EqualityExpr",
 										},
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 331, col: 22, offset: 8258},
+									pos:   position{line: 859, col: 22, offset: 27033},
 									label: "term",
-									expr: &ruleRefExpr{
-										pos:  position{line: 331, col: 27, offset: 8263},
-										name: "DecimalOrIntExp",
+									expr: &choiceExpr{
+										pos: position{line: 859, col: 28, offset: 27039},
+										alternatives: []interface{}{
+											&ruleRefExpr{
+												pos:  position{line: 859, col: 28, offset: 27039},
+												name: "DecimalCommaExp",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 859, col: 46, offset: 27057},
+												name: "ByteSizeExp",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 859, col: 60, offset: 27071},
+												name: "DecimalOrIntExp",
+											},
+										},
 									},
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 331, col: 43, offset: 8279},
+									pos: position{line: 859, col: 77, offset: 27088},
 									expr: &ruleRefExpr{
-										pos:  position{line: 331, col: 43, offset: 8279},
+										pos:  position{line: 859, col: 77, offset: 27088},
 										name: "_",
 									},
 								},
@@ -643,70 +1622,70 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 338, col: 5, offset: 8396},
-						run: (*parser).callonTerm11,
+						pos: position{line: 866, col: 5, offset: 27205},
+						run: (*parser).callonTerm22,
 						expr: &seqExpr{
-							pos: position{line: 338, col: 5, offset: 8396},
+							pos: position{line: 866, col: 5, offset: 27205},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 338, col: 5, offset: 8396},
+									pos:   position{line: 866, col: 5, offset: 27205},
 									label: "eq",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 338, col: 8, offset: 8399},
+										pos: position{line: 866, col: 8, offset: 27208},
 										expr: &ruleRefExpr{
-											pos:  position{line: 338, col: 8, offset: 8399},
+											pos:  position{line: 866, col: 8, offset: 27208},
 											name: "EqualityExpr",
 										},
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 338, col: 22, offset: 8413},
+									pos:   position{line: 866, col: 22, offset: 27222},
 									label: "op",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 338, col: 25, offset: 8416},
+										pos: position{line: 866, col: 25, offset: 27225},
 										expr: &ruleRefExpr{
-											pos:  position{line: 338, col: 25, offset: 8416},
+											pos:  position{line: 866, col: 25, offset: 27225},
 											name: "PrefixOperatorExp",
 										},
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 338, col: 44, offset: 8435},
+									pos:   position{line: 866, col: 44, offset: 27244},
 									label: "term",
 									expr: &choiceExpr{
-										pos: position{line: 338, col: 50, offset: 8441},
+										pos: position{line: 866, col: 50, offset: 27250},
 										alternatives: []interface{}{
 											&ruleRefExpr{
-												pos:  position{line: 338, col: 50, offset: 8441},
+												pos:  position{line: 866, col: 50, offset: 27250},
 												name: "Null",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 338, col: 57, offset: 8448},
+												pos:  position{line: 866, col: 57, offset: 27257},
 												name: "Bool",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 338, col: 64, offset: 8455},
+												pos:  position{line: 866, col: 64, offset: 27264},
 												name: "DecimalOrIntExp",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 338, col: 82, offset: 8473},
+												pos:  position{line: 866, col: 82, offset: 27282},
 												name: "WildCardExp",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 338, col: 96, offset: 8487},
+												pos:  position{line: 866, col: 96, offset: 27296},
 												name: "QuotedTerm",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 338, col: 109, offset: 8500},
+												pos:  position{line: 866, col: 109, offset: 27309},
 												name: "UnquotedTerm",
 											},
 										},
 									},
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 338, col: 123, offset: 8514},
+									pos: position{line: 866, col: 123, offset: 27323},
 									expr: &ruleRefExpr{
-										pos:  position{line: 338, col: 123, offset: 8514},
+										pos:  position{line: 866, col: 123, offset: 27323},
 										name: "_",
 									},
 								},
@@ -718,17 +1697,17 @@ var g = &grammar{
 		},
 		{
 			name: "UnquotedTerm",
-			pos:  position{line: 347, col: 1, offset: 8666},
+			pos:  position{line: 875, col: 1, offset: 27475},
 			expr: &actionExpr{
-				pos: position{line: 348, col: 5, offset: 8683},
+				pos: position{line: 876, col: 5, offset: 27492},
 				run: (*parser).callonUnquotedTerm1,
 				expr: &labeledExpr{
-					pos:   position{line: 348, col: 5, offset: 8683},
+					pos:   position{line: 876, col: 5, offset: 27492},
 					label: "term",
 					expr: &oneOrMoreExpr{
-						pos: position{line: 348, col: 10, offset: 8688},
+						pos: position{line: 876, col: 10, offset: 27497},
 						expr: &ruleRefExpr{
-							pos:  position{line: 348, col: 10, offset: 8688},
+							pos:  position{line: 876, col: 10, offset: 27497},
 							name: "TermChar",
 						},
 					},
@@ -737,72 +1716,108 @@ var g = &grammar{
 		},
 		{
 			name: "TermChar",
-			pos:  position{line: 353, col: 1, offset: 8747},
+			pos:  position{line: 881, col: 1, offset: 27556},
 			expr: &choiceExpr{
-				pos: position{line: 354, col: 5, offset: 8760},
+				pos: position{line: 882, col: 5, offset: 27569},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 354, col: 5, offset: 8760},
+						pos:        position{line: 882, col: 5, offset: 27569},
 						val:        ".",
 						ignoreCase: false,
 						want:       "\".\"",
 					},
 					&charClassMatcher{
-						pos:        position{line: 354, col: 11, offset: 8766},
-						val:        "[^: \\t\\r\\n)({}\"^~\\\\[\\]*+-]",
-						chars:      []rune{':', ' ', '\t', '\r', '\n', ')', '(', '{', '}', '"', '^', '~', '\\', '[', ']', '*', '+', '-'},
+						pos:        position{line: 882, col: 11, offset: 27575},
+						val:        "[^: \\t\\r\\n\\u00A0)({}\"^~\\\\[\\]*+-]",
+						chars:      []rune{':', ' ', '\t', '\r', '\n', '\u00a0', ')', '(', '{', '}', '"', '^', '~', '\\', '[', ']', '*', '+', '-'},
 						ignoreCase: false,
 						inverted:   true,
 					},
 				},
 			},
 		},
+		{
+			name: "ColonTerm",
+			pos:  position{line: 884, col: 1, offset: 27609},
+			expr: &actionExpr{
+				pos: position{line: 885, col: 5, offset: 27623},
+				run: (*parser).callonColonTerm1,
+				expr: &seqExpr{
+					pos: position{line: 885, col: 5, offset: 27623},
+					exprs: []interface{}{
+						&oneOrMoreExpr{
+							pos: position{line: 885, col: 5, offset: 27623},
+							expr: &ruleRefExpr{
+								pos:  position{line: 885, col: 5, offset: 27623},
+								name: "TermChar",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 885, col: 15, offset: 27633},
+							val:        ":",
+							ignoreCase: false,
+							want:       "\":\"",
+						},
+						&oneOrMoreExpr{
+							pos: position{line: 885, col: 19, offset: 27637},
+							expr: &charClassMatcher{
+								pos:        position{line: 885, col: 19, offset: 27637},
+								val:        "[^ \\t\\r\\n\\u00A0)(]",
+								chars:      []rune{' ', '\t', '\r', '\n', '\u00a0', ')', '('},
+								ignoreCase: false,
+								inverted:   true,
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "QuotedTerm",
-			pos:  position{line: 356, col: 1, offset: 8794},
+			pos:  position{line: 890, col: 1, offset: 27705},
 			expr: &actionExpr{
-				pos: position{line: 357, col: 5, offset: 8809},
+				pos: position{line: 891, col: 5, offset: 27720},
 				run: (*parser).callonQuotedTerm1,
 				expr: &seqExpr{
-					pos: position{line: 357, col: 5, offset: 8809},
+					pos: position{line: 891, col: 5, offset: 27720},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 357, col: 5, offset: 8809},
+							pos:        position{line: 891, col: 5, offset: 27720},
 							val:        "\"",
 							ignoreCase: false,
 							want:       "\"\\\"\"",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 357, col: 9, offset: 8813},
+							pos: position{line: 891, col: 9, offset: 27724},
 							expr: &choiceExpr{
-								pos: position{line: 357, col: 10, offset: 8814},
+								pos: position{line: 891, col: 10, offset: 27725},
 								alternatives: []interface{}{
 									&seqExpr{
-										pos: position{line: 357, col: 10, offset: 8814},
+										pos: position{line: 891, col: 10, offset: 27725},
 										exprs: []interface{}{
 											&notExpr{
-												pos: position{line: 357, col: 10, offset: 8814},
+												pos: position{line: 891, col: 10, offset: 27725},
 												expr: &ruleRefExpr{
-													pos:  position{line: 357, col: 11, offset: 8815},
+													pos:  position{line: 891, col: 11, offset: 27726},
 													name: "EscapedChar",
 												},
 											},
 											&anyMatcher{
-												line: 357, col: 23, offset: 8827,
+												line: 891, col: 23, offset: 27738,
 											},
 										},
 									},
 									&seqExpr{
-										pos: position{line: 357, col: 27, offset: 8831},
+										pos: position{line: 891, col: 27, offset: 27742},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 357, col: 27, offset: 8831},
+												pos:        position{line: 891, col: 27, offset: 27742},
 												val:        "\\",
 												ignoreCase: false,
 												want:       "\"\\\\\"",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 357, col: 32, offset: 8836},
+												pos:  position{line: 891, col: 32, offset: 27747},
 												name: "EscapeSequence",
 											},
 										},
@@ -811,7 +1826,7 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 357, col: 49, offset: 8853},
+							pos:        position{line: 891, col: 49, offset: 27764},
 							val:        "\"",
 							ignoreCase: false,
 							want:       "\"\\\"\"",
@@ -822,46 +1837,50 @@ var g = &grammar{
 		},
 		{
 			name: "ArrayValue",
-			pos:  position{line: 363, col: 1, offset: 8987},
+			pos:  position{line: 897, col: 1, offset: 27898},
 			expr: &actionExpr{
-				pos: position{line: 363, col: 15, offset: 9001},
+				pos: position{line: 897, col: 15, offset: 27912},
 				run: (*parser).callonArrayValue1,
 				expr: &seqExpr{
-					pos: position{line: 363, col: 15, offset: 9001},
+					pos: position{line: 897, col: 15, offset: 27912},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 363, col: 15, offset: 9001},
+							pos:   position{line: 897, col: 15, offset: 27912},
 							label: "val",
 							expr: &choiceExpr{
-								pos: position{line: 363, col: 20, offset: 9006},
+								pos: position{line: 897, col: 20, offset: 27917},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 363, col: 20, offset: 9006},
+										pos:  position{line: 897, col: 20, offset: 27917},
 										name: "Null",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 363, col: 27, offset: 9013},
+										pos:  position{line: 897, col: 27, offset: 27924},
 										name: "Bool",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 363, col: 33, offset: 9019},
+										pos:  position{line: 897, col: 34, offset: 27931},
+										name: "ByteSizeExp",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 897, col: 48, offset: 27945},
 										name: "DecimalOrIntExp",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 363, col: 51, offset: 9037},
+										pos:  position{line: 897, col: 66, offset: 27963},
 										name: "QuotedTerm",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 363, col: 64, offset: 9050},
+										pos:  position{line: 897, col: 79, offset: 27976},
 										name: "UnquotedTerm",
 									},
 								},
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 363, col: 79, offset: 9065},
+							pos: position{line: 897, col: 94, offset: 27991},
 							expr: &ruleRefExpr{
-								pos:  position{line: 363, col: 79, offset: 9065},
+								pos:  position{line: 897, col: 94, offset: 27991},
 								name: "_",
 							},
 						},
@@ -871,58 +1890,58 @@ var g = &grammar{
 		},
 		{
 			name: "ArrayExp",
-			pos:  position{line: 367, col: 1, offset: 9093},
+			pos:  position{line: 901, col: 1, offset: 28019},
 			expr: &actionExpr{
-				pos: position{line: 367, col: 13, offset: 9105},
+				pos: position{line: 901, col: 13, offset: 28031},
 				run: (*parser).callonArrayExp1,
 				expr: &seqExpr{
-					pos: position{line: 367, col: 13, offset: 9105},
+					pos: position{line: 901, col: 13, offset: 28031},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 367, col: 13, offset: 9105},
+							pos:        position{line: 901, col: 13, offset: 28031},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 367, col: 17, offset: 9109},
+							pos: position{line: 901, col: 17, offset: 28035},
 							expr: &ruleRefExpr{
-								pos:  position{line: 367, col: 17, offset: 9109},
+								pos:  position{line: 901, col: 17, offset: 28035},
 								name: "_",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 367, col: 20, offset: 9112},
+							pos:   position{line: 901, col: 20, offset: 28038},
 							label: "vals",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 367, col: 25, offset: 9117},
+								pos: position{line: 901, col: 25, offset: 28043},
 								expr: &seqExpr{
-									pos: position{line: 367, col: 26, offset: 9118},
+									pos: position{line: 901, col: 26, offset: 28044},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 367, col: 26, offset: 9118},
+											pos:  position{line: 901, col: 26, offset: 28044},
 											name: "ArrayValue",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 367, col: 37, offset: 9129},
+											pos: position{line: 901, col: 37, offset: 28055},
 											expr: &seqExpr{
-												pos: position{line: 367, col: 38, offset: 9130},
+												pos: position{line: 901, col: 38, offset: 28056},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 367, col: 38, offset: 9130},
+														pos:        position{line: 901, col: 38, offset: 28056},
 														val:        ",",
 														ignoreCase: false,
 														want:       "\",\"",
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 367, col: 42, offset: 9134},
+														pos: position{line: 901, col: 42, offset: 28060},
 														expr: &ruleRefExpr{
-															pos:  position{line: 367, col: 42, offset: 9134},
+															pos:  position{line: 901, col: 42, offset: 28060},
 															name: "_",
 														},
 													},
 													&ruleRefExpr{
-														pos:  position{line: 367, col: 45, offset: 9137},
+														pos:  position{line: 901, col: 45, offset: 28063},
 														name: "ArrayValue",
 													},
 												},
@@ -933,14 +1952,14 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 367, col: 60, offset: 9152},
+							pos: position{line: 901, col: 60, offset: 28078},
 							expr: &ruleRefExpr{
-								pos:  position{line: 367, col: 60, offset: 9152},
+								pos:  position{line: 901, col: 60, offset: 28078},
 								name: "_",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 367, col: 63, offset: 9155},
+							pos:        position{line: 901, col: 63, offset: 28081},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
@@ -949,18 +1968,104 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "DecimalCommaExp",
+			pos:  position{line: 915, col: 1, offset: 28387},
+			expr: &actionExpr{
+				pos: position{line: 916, col: 5, offset: 28407},
+				run: (*parser).callonDecimalCommaExp1,
+				expr: &seqExpr{
+					pos: position{line: 916, col: 5, offset: 28407},
+					exprs: []interface{}{
+						&andCodeExpr{
+							pos: position{line: 916, col: 5, offset: 28407},
+							run: (*parser).callonDecimalCommaExp3,
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 916, col: 38, offset: 28440},
+							expr: &litMatcher{
+								pos:        position{line: 916, col: 38, offset: 28440},
+								val:        "-",
+								ignoreCase: false,
+								want:       "\"-\"",
+							},
+						},
+						&oneOrMoreExpr{
+							pos: position{line: 916, col: 43, offset: 28445},
+							expr: &charClassMatcher{
+								pos:        position{line: 916, col: 43, offset: 28445},
+								val:        "[0-9]",
+								ranges:     []rune{'0', '9'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 916, col: 50, offset: 28452},
+							val:        ",",
+							ignoreCase: false,
+							want:       "\",\"",
+						},
+						&oneOrMoreExpr{
+							pos: position{line: 916, col: 54, offset: 28456},
+							expr: &charClassMatcher{
+								pos:        position{line: 916, col: 54, offset: 28456},
+								val:        "[0-9]",
+								ranges:     []rune{'0', '9'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
+						&notExpr{
+							pos: position{line: 916, col: 61, offset: 28463},
+							expr: &charClassMatcher{
+								pos:        position{line: 916, col: 62, offset: 28464},
+								val:        "[a-zA-Z0-9_,]",
+								chars:      []rune{'_', ','},
+								ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
+						&notExpr{
+							pos: position{line: 916, col: 76, offset: 28478},
+							expr: &seqExpr{
+								pos: position{line: 916, col: 78, offset: 28480},
+								exprs: []interface{}{
+									&litMatcher{
+										pos:        position{line: 916, col: 78, offset: 28480},
+										val:        ".",
+										ignoreCase: false,
+										want:       "\".\"",
+									},
+									&notExpr{
+										pos: position{line: 916, col: 82, offset: 28484},
+										expr: &litMatcher{
+											pos:        position{line: 916, col: 83, offset: 28485},
+											val:        ".",
+											ignoreCase: false,
+											want:       "\".\"",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "DecimalOrIntExp",
-			pos:  position{line: 381, col: 1, offset: 9461},
+			pos:  position{line: 921, col: 1, offset: 28587},
 			expr: &choiceExpr{
-				pos: position{line: 382, col: 4, offset: 9480},
+				pos: position{line: 922, col: 4, offset: 28606},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 382, col: 4, offset: 9480},
+						pos:  position{line: 922, col: 4, offset: 28606},
 						name: "DecimalExp",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 383, col: 4, offset: 9494},
+						pos:  position{line: 923, col: 4, offset: 28620},
 						name: "IntExp",
 					},
 				},
@@ -968,47 +2073,104 @@ var g = &grammar{
 		},
 		{
 			name: "DecimalExp",
-			pos:  position{line: 386, col: 1, offset: 9503},
+			pos:  position{line: 926, col: 1, offset: 28629},
 			expr: &actionExpr{
-				pos: position{line: 387, col: 4, offset: 9517},
+				pos: position{line: 927, col: 4, offset: 28643},
 				run: (*parser).callonDecimalExp1,
 				expr: &seqExpr{
-					pos: position{line: 387, col: 4, offset: 9517},
+					pos: position{line: 927, col: 4, offset: 28643},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 387, col: 4, offset: 9517},
+							pos: position{line: 927, col: 4, offset: 28643},
 							expr: &litMatcher{
-								pos:        position{line: 387, col: 4, offset: 9517},
+								pos:        position{line: 927, col: 4, offset: 28643},
 								val:        "-",
 								ignoreCase: false,
 								want:       "\"-\"",
 							},
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 387, col: 9, offset: 9522},
+							pos: position{line: 927, col: 9, offset: 28648},
 							expr: &charClassMatcher{
-								pos:        position{line: 387, col: 9, offset: 9522},
+								pos:        position{line: 927, col: 9, offset: 28648},
 								val:        "[0-9]",
 								ranges:     []rune{'0', '9'},
 								ignoreCase: false,
 								inverted:   false,
 							},
 						},
-						&litMatcher{
-							pos:        position{line: 387, col: 16, offset: 9529},
-							val:        ".",
+						&choiceExpr{
+							pos: position{line: 927, col: 17, offset: 28656},
+							alternatives: []interface{}{
+								&seqExpr{
+									pos: position{line: 927, col: 17, offset: 28656},
+									exprs: []interface{}{
+										&litMatcher{
+											pos:        position{line: 927, col: 17, offset: 28656},
+											val:        ".",
+											ignoreCase: false,
+											want:       "\".\"",
+										},
+										&oneOrMoreExpr{
+											pos: position{line: 927, col: 21, offset: 28660},
+											expr: &charClassMatcher{
+												pos:        position{line: 927, col: 21, offset: 28660},
+												val:        "[0-9]",
+												ranges:     []rune{'0', '9'},
+												ignoreCase: false,
+												inverted:   false,
+											},
+										},
+										&zeroOrOneExpr{
+											pos: position{line: 927, col: 28, offset: 28667},
+											expr: &ruleRefExpr{
+												pos:  position{line: 927, col: 28, offset: 28667},
+												name: "ExponentExp",
+											},
+										},
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 927, col: 43, offset: 28682},
+									name: "ExponentExp",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ExponentExp",
+			pos:  position{line: 937, col: 1, offset: 28957},
+			expr: &seqExpr{
+				pos: position{line: 938, col: 4, offset: 28972},
+				exprs: []interface{}{
+					&charClassMatcher{
+						pos:        position{line: 938, col: 4, offset: 28972},
+						val:        "[eE]",
+						chars:      []rune{'e', 'E'},
+						ignoreCase: false,
+						inverted:   false,
+					},
+					&zeroOrOneExpr{
+						pos: position{line: 938, col: 9, offset: 28977},
+						expr: &charClassMatcher{
+							pos:        position{line: 938, col: 9, offset: 28977},
+							val:        "[+-]",
+							chars:      []rune{'+', '-'},
 							ignoreCase: false,
-							want:       "\".\"",
+							inverted:   false,
 						},
-						&oneOrMoreExpr{
-							pos: position{line: 387, col: 20, offset: 9533},
-							expr: &charClassMatcher{
-								pos:        position{line: 387, col: 20, offset: 9533},
-								val:        "[0-9]",
-								ranges:     []rune{'0', '9'},
-								ignoreCase: false,
-								inverted:   false,
-							},
+					},
+					&oneOrMoreExpr{
+						pos: position{line: 938, col: 15, offset: 28983},
+						expr: &charClassMatcher{
+							pos:        position{line: 938, col: 15, offset: 28983},
+							val:        "[0-9]",
+							ranges:     []rune{'0', '9'},
+							ignoreCase: false,
+							inverted:   false,
 						},
 					},
 				},
@@ -1016,26 +2178,26 @@ var g = &grammar{
 		},
 		{
 			name: "IntExp",
-			pos:  position{line: 392, col: 1, offset: 9630},
+			pos:  position{line: 940, col: 1, offset: 28991},
 			expr: &actionExpr{
-				pos: position{line: 393, col: 5, offset: 9641},
+				pos: position{line: 941, col: 5, offset: 29002},
 				run: (*parser).callonIntExp1,
 				expr: &seqExpr{
-					pos: position{line: 393, col: 5, offset: 9641},
+					pos: position{line: 941, col: 5, offset: 29002},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 393, col: 5, offset: 9641},
+							pos: position{line: 941, col: 5, offset: 29002},
 							expr: &litMatcher{
-								pos:        position{line: 393, col: 5, offset: 9641},
+								pos:        position{line: 941, col: 5, offset: 29002},
 								val:        "-",
 								ignoreCase: false,
 								want:       "\"-\"",
 							},
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 393, col: 10, offset: 9646},
+							pos: position{line: 941, col: 10, offset: 29007},
 							expr: &charClassMatcher{
-								pos:        position{line: 393, col: 10, offset: 9646},
+								pos:        position{line: 941, col: 10, offset: 29007},
 								val:        "[0-9]",
 								ranges:     []rune{'0', '9'},
 								ignoreCase: false,
@@ -1046,103 +2208,209 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "ByteSizeExp",
+			pos:  position{line: 946, col: 1, offset: 29072},
+			expr: &actionExpr{
+				pos: position{line: 947, col: 5, offset: 29088},
+				run: (*parser).callonByteSizeExp1,
+				expr: &seqExpr{
+					pos: position{line: 947, col: 5, offset: 29088},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 947, col: 5, offset: 29088},
+							label: "size",
+							expr: &ruleRefExpr{
+								pos:  position{line: 947, col: 10, offset: 29093},
+								name: "DecimalOrIntExp",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 947, col: 26, offset: 29109},
+							label: "unit",
+							expr: &choiceExpr{
+								pos: position{line: 947, col: 32, offset: 29115},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 947, col: 32, offset: 29115},
+										val:        "kb",
+										ignoreCase: true,
+										want:       "\"kb\"i",
+									},
+									&litMatcher{
+										pos:        position{line: 947, col: 40, offset: 29123},
+										val:        "mb",
+										ignoreCase: true,
+										want:       "\"mb\"i",
+									},
+									&litMatcher{
+										pos:        position{line: 947, col: 48, offset: 29131},
+										val:        "gb",
+										ignoreCase: true,
+										want:       "\"gb\"i",
+									},
+								},
+							},
+						},
+						&notExpr{
+							pos: position{line: 947, col: 55, offset: 29138},
+							expr: &charClassMatcher{
+								pos:        position{line: 947, col: 56, offset: 29139},
+								val:        "[a-zA-Z0-9_]",
+								chars:      []rune{'_'},
+								ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
+						&notExpr{
+							pos: position{line: 947, col: 69, offset: 29152},
+							expr: &seqExpr{
+								pos: position{line: 947, col: 71, offset: 29154},
+								exprs: []interface{}{
+									&litMatcher{
+										pos:        position{line: 947, col: 71, offset: 29154},
+										val:        ".",
+										ignoreCase: false,
+										want:       "\".\"",
+									},
+									&notExpr{
+										pos: position{line: 947, col: 75, offset: 29158},
+										expr: &litMatcher{
+											pos:        position{line: 947, col: 76, offset: 29159},
+											val:        ".",
+											ignoreCase: false,
+											want:       "\".\"",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "RangeOperatorExp",
-			pos:  position{line: 398, col: 1, offset: 9711},
+			pos:  position{line: 966, col: 1, offset: 29661},
 			expr: &choiceExpr{
-				pos: position{line: 399, col: 6, offset: 9733},
+				pos: position{line: 967, col: 6, offset: 29683},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 399, col: 6, offset: 9733},
+						pos: position{line: 967, col: 6, offset: 29683},
 						run: (*parser).callonRangeOperatorExp2,
 						expr: &seqExpr{
-							pos: position{line: 399, col: 6, offset: 9733},
+							pos: position{line: 967, col: 6, offset: 29683},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 399, col: 6, offset: 9733},
+									pos:        position{line: 967, col: 6, offset: 29683},
 									val:        "[",
 									ignoreCase: false,
 									want:       "\"[\"",
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 399, col: 11, offset: 9738},
+									pos: position{line: 967, col: 11, offset: 29688},
 									expr: &ruleRefExpr{
-										pos:  position{line: 399, col: 11, offset: 9738},
+										pos:  position{line: 967, col: 11, offset: 29688},
 										name: "_",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 399, col: 14, offset: 9741},
+									pos:   position{line: 967, col: 14, offset: 29691},
 									label: "termMin",
 									expr: &choiceExpr{
-										pos: position{line: 399, col: 23, offset: 9750},
+										pos: position{line: 967, col: 23, offset: 29700},
 										alternatives: []interface{}{
 											&ruleRefExpr{
-												pos:  position{line: 399, col: 23, offset: 9750},
+												pos:  position{line: 967, col: 23, offset: 29700},
+												name: "DecimalCommaExp",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 967, col: 41, offset: 29718},
+												name: "ByteSizeExp",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 967, col: 55, offset: 29732},
 												name: "DecimalOrIntExp",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 399, col: 41, offset: 9768},
+												pos:  position{line: 967, col: 73, offset: 29750},
 												name: "WildCard",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 399, col: 52, offset: 9779},
+												pos:  position{line: 967, col: 84, offset: 29761},
+												name: "RangeAnchor",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 967, col: 98, offset: 29775},
 												name: "UnquotedTerm",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 399, col: 67, offset: 9794},
+												pos:  position{line: 967, col: 113, offset: 29790},
 												name: "QuotedTerm",
 											},
 										},
 									},
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 399, col: 79, offset: 9806},
+									pos: position{line: 967, col: 125, offset: 29802},
 									expr: &ruleRefExpr{
-										pos:  position{line: 399, col: 79, offset: 9806},
+										pos:  position{line: 967, col: 125, offset: 29802},
 										name: "_",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 399, col: 82, offset: 9809},
+									pos:        position{line: 967, col: 128, offset: 29805},
 									val:        "TO",
 									ignoreCase: false,
 									want:       "\"TO\"",
 								},
 								&oneOrMoreExpr{
-									pos: position{line: 399, col: 87, offset: 9814},
+									pos: position{line: 967, col: 133, offset: 29810},
 									expr: &ruleRefExpr{
-										pos:  position{line: 399, col: 87, offset: 9814},
+										pos:  position{line: 967, col: 133, offset: 29810},
 										name: "_",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 399, col: 90, offset: 9817},
+									pos:   position{line: 967, col: 136, offset: 29813},
 									label: "termMax",
 									expr: &choiceExpr{
-										pos: position{line: 399, col: 99, offset: 9826},
+										pos: position{line: 967, col: 145, offset: 29822},
 										alternatives: []interface{}{
 											&ruleRefExpr{
-												pos:  position{line: 399, col: 99, offset: 9826},
+												pos:  position{line: 967, col: 145, offset: 29822},
+												name: "DecimalCommaExp",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 967, col: 163, offset: 29840},
+												name: "ByteSizeExp",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 967, col: 177, offset: 29854},
 												name: "DecimalOrIntExp",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 399, col: 117, offset: 9844},
+												pos:  position{line: 967, col: 195, offset: 29872},
 												name: "WildCard",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 399, col: 128, offset: 9855},
+												pos:  position{line: 967, col: 206, offset: 29883},
+												name: "RangeAnchor",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 967, col: 220, offset: 29897},
 												name: "UnquotedTerm",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 399, col: 143, offset: 9870},
+												pos:  position{line: 967, col: 235, offset: 29912},
 												name: "QuotedTerm",
 											},
 										},
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 399, col: 155, offset: 9882},
+									pos:        position{line: 967, col: 247, offset: 29924},
 									val:        "]",
 									ignoreCase: false,
 									want:       "\"]\"",
@@ -1151,89 +2419,113 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 407, col: 5, offset: 10038},
-						run: (*parser).callonRangeOperatorExp25,
+						pos: position{line: 976, col: 5, offset: 30137},
+						run: (*parser).callonRangeOperatorExp31,
 						expr: &seqExpr{
-							pos: position{line: 407, col: 5, offset: 10038},
+							pos: position{line: 976, col: 5, offset: 30137},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 407, col: 5, offset: 10038},
+									pos:        position{line: 976, col: 5, offset: 30137},
 									val:        "{",
 									ignoreCase: false,
 									want:       "\"{\"",
 								},
 								&labeledExpr{
-									pos:   position{line: 407, col: 9, offset: 10042},
+									pos:   position{line: 976, col: 9, offset: 30141},
 									label: "termMin",
 									expr: &choiceExpr{
-										pos: position{line: 407, col: 18, offset: 10051},
+										pos: position{line: 976, col: 18, offset: 30150},
 										alternatives: []interface{}{
 											&ruleRefExpr{
-												pos:  position{line: 407, col: 18, offset: 10051},
+												pos:  position{line: 976, col: 18, offset: 30150},
+												name: "DecimalCommaExp",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 976, col: 36, offset: 30168},
+												name: "ByteSizeExp",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 976, col: 50, offset: 30182},
 												name: "DecimalOrIntExp",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 407, col: 36, offset: 10069},
+												pos:  position{line: 976, col: 68, offset: 30200},
 												name: "WildCard",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 407, col: 47, offset: 10080},
+												pos:  position{line: 976, col: 79, offset: 30211},
+												name: "RangeAnchor",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 976, col: 93, offset: 30225},
 												name: "UnquotedTerm",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 407, col: 62, offset: 10095},
+												pos:  position{line: 976, col: 108, offset: 30240},
 												name: "QuotedTerm",
 											},
 										},
 									},
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 407, col: 74, offset: 10107},
+									pos: position{line: 976, col: 120, offset: 30252},
 									expr: &ruleRefExpr{
-										pos:  position{line: 407, col: 74, offset: 10107},
+										pos:  position{line: 976, col: 120, offset: 30252},
 										name: "_",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 407, col: 77, offset: 10110},
+									pos:        position{line: 976, col: 123, offset: 30255},
 									val:        "TO",
 									ignoreCase: false,
 									want:       "\"TO\"",
 								},
 								&oneOrMoreExpr{
-									pos: position{line: 407, col: 82, offset: 10115},
+									pos: position{line: 976, col: 128, offset: 30260},
 									expr: &ruleRefExpr{
-										pos:  position{line: 407, col: 82, offset: 10115},
+										pos:  position{line: 976, col: 128, offset: 30260},
 										name: "_",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 407, col: 85, offset: 10118},
+									pos:   position{line: 976, col: 131, offset: 30263},
 									label: "termMax",
 									expr: &choiceExpr{
-										pos: position{line: 407, col: 94, offset: 10127},
+										pos: position{line: 976, col: 140, offset: 30272},
 										alternatives: []interface{}{
 											&ruleRefExpr{
-												pos:  position{line: 407, col: 94, offset: 10127},
+												pos:  position{line: 976, col: 140, offset: 30272},
+												name: "DecimalCommaExp",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 976, col: 158, offset: 30290},
+												name: "ByteSizeExp",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 976, col: 172, offset: 30304},
 												name: "DecimalOrIntExp",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 407, col: 112, offset: 10145},
+												pos:  position{line: 976, col: 190, offset: 30322},
 												name: "WildCard",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 407, col: 123, offset: 10156},
+												pos:  position{line: 976, col: 201, offset: 30333},
+												name: "RangeAnchor",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 976, col: 215, offset: 30347},
 												name: "UnquotedTerm",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 407, col: 138, offset: 10171},
+												pos:  position{line: 976, col: 230, offset: 30362},
 												name: "QuotedTerm",
 											},
 										},
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 407, col: 151, offset: 10184},
+									pos:        position{line: 976, col: 243, offset: 30375},
 									val:        "}",
 									ignoreCase: false,
 									want:       "\"}\"",
@@ -1244,37 +2536,189 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "DotRangeExp",
+			pos:  position{line: 986, col: 1, offset: 30585},
+			expr: &choiceExpr{
+				pos: position{line: 987, col: 5, offset: 30601},
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 987, col: 5, offset: 30601},
+						run: (*parser).callonDotRangeExp2,
+						expr: &seqExpr{
+							pos: position{line: 987, col: 5, offset: 30601},
+							exprs: []interface{}{
+								&labeledExpr{
+									pos:   position{line: 987, col: 5, offset: 30601},
+									label: "minOp",
+									expr: &zeroOrOneExpr{
+										pos: position{line: 987, col: 11, offset: 30607},
+										expr: &litMatcher{
+											pos:        position{line: 987, col: 11, offset: 30607},
+											val:        ">",
+											ignoreCase: false,
+											want:       "\">\"",
+										},
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 987, col: 16, offset: 30612},
+									label: "min",
+									expr: &ruleRefExpr{
+										pos:  position{line: 987, col: 20, offset: 30616},
+										name: "RangeBound",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 987, col: 31, offset: 30627},
+									val:        "..",
+									ignoreCase: false,
+									want:       "\"..\"",
+								},
+								&labeledExpr{
+									pos:   position{line: 987, col: 36, offset: 30632},
+									label: "maxOp",
+									expr: &zeroOrOneExpr{
+										pos: position{line: 987, col: 42, offset: 30638},
+										expr: &litMatcher{
+											pos:        position{line: 987, col: 42, offset: 30638},
+											val:        "<",
+											ignoreCase: false,
+											want:       "\"<\"",
+										},
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 987, col: 47, offset: 30643},
+									label: "max",
+									expr: &zeroOrOneExpr{
+										pos: position{line: 987, col: 51, offset: 30647},
+										expr: &ruleRefExpr{
+											pos:  position{line: 987, col: 51, offset: 30647},
+											name: "RangeBound",
+										},
+									},
+								},
+								&notExpr{
+									pos: position{line: 987, col: 63, offset: 30659},
+									expr: &charClassMatcher{
+										pos:        position{line: 987, col: 64, offset: 30660},
+										val:        "[a-zA-Z0-9_.]",
+										chars:      []rune{'_', '.'},
+										ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 991, col: 5, offset: 30757},
+						run: (*parser).callonDotRangeExp18,
+						expr: &seqExpr{
+							pos: position{line: 991, col: 5, offset: 30757},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 991, col: 5, offset: 30757},
+									val:        "..",
+									ignoreCase: false,
+									want:       "\"..\"",
+								},
+								&labeledExpr{
+									pos:   position{line: 991, col: 10, offset: 30762},
+									label: "maxOp",
+									expr: &zeroOrOneExpr{
+										pos: position{line: 991, col: 16, offset: 30768},
+										expr: &litMatcher{
+											pos:        position{line: 991, col: 16, offset: 30768},
+											val:        "<",
+											ignoreCase: false,
+											want:       "\"<\"",
+										},
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 991, col: 21, offset: 30773},
+									label: "max",
+									expr: &ruleRefExpr{
+										pos:  position{line: 991, col: 25, offset: 30777},
+										name: "RangeBound",
+									},
+								},
+								&notExpr{
+									pos: position{line: 991, col: 36, offset: 30788},
+									expr: &charClassMatcher{
+										pos:        position{line: 991, col: 37, offset: 30789},
+										val:        "[a-zA-Z0-9_.]",
+										chars:      []rune{'_', '.'},
+										ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "RangeBound",
+			pos:  position{line: 996, col: 1, offset: 30875},
+			expr: &choiceExpr{
+				pos: position{line: 997, col: 5, offset: 30890},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 997, col: 5, offset: 30890},
+						name: "DecimalCommaExp",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 997, col: 23, offset: 30908},
+						name: "ByteSizeExp",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 997, col: 37, offset: 30922},
+						name: "DecimalOrIntExp",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 997, col: 55, offset: 30940},
+						name: "QuotedTerm",
+					},
+				},
+			},
+		},
 		{
 			name: "OperatorExp",
-			pos:  position{line: 416, col: 1, offset: 10337},
+			pos:  position{line: 999, col: 1, offset: 30952},
 			expr: &choiceExpr{
-				pos: position{line: 417, col: 5, offset: 10353},
+				pos: position{line: 1000, col: 5, offset: 30968},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 417, col: 5, offset: 10353},
+						pos: position{line: 1000, col: 5, offset: 30968},
 						run: (*parser).callonOperatorExp2,
 						expr: &seqExpr{
-							pos: position{line: 417, col: 5, offset: 10353},
+							pos: position{line: 1000, col: 5, offset: 30968},
 							exprs: []interface{}{
 								&zeroOrMoreExpr{
-									pos: position{line: 417, col: 5, offset: 10353},
+									pos: position{line: 1000, col: 5, offset: 30968},
 									expr: &ruleRefExpr{
-										pos:  position{line: 417, col: 5, offset: 10353},
+										pos:  position{line: 1000, col: 5, offset: 30968},
 										name: "_",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 417, col: 8, offset: 10356},
+									pos:   position{line: 1000, col: 8, offset: 30971},
 									label: "operator",
 									expr: &ruleRefExpr{
-										pos:  position{line: 417, col: 17, offset: 10365},
+										pos:  position{line: 1000, col: 17, offset: 30980},
 										name: "Operator",
 									},
 								},
 								&oneOrMoreExpr{
-									pos: position{line: 417, col: 26, offset: 10374},
+									pos: position{line: 1000, col: 26, offset: 30989},
 									expr: &ruleRefExpr{
-										pos:  position{line: 417, col: 26, offset: 10374},
+										pos:  position{line: 1000, col: 26, offset: 30989},
 										name: "_",
 									},
 								},
@@ -1282,28 +2726,28 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 421, col: 5, offset: 10434},
+						pos: position{line: 1004, col: 5, offset: 31049},
 						run: (*parser).callonOperatorExp10,
 						expr: &seqExpr{
-							pos: position{line: 421, col: 5, offset: 10434},
+							pos: position{line: 1004, col: 5, offset: 31049},
 							exprs: []interface{}{
 								&zeroOrMoreExpr{
-									pos: position{line: 421, col: 5, offset: 10434},
+									pos: position{line: 1004, col: 5, offset: 31049},
 									expr: &ruleRefExpr{
-										pos:  position{line: 421, col: 5, offset: 10434},
+										pos:  position{line: 1004, col: 5, offset: 31049},
 										name: "_",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 421, col: 8, offset: 10437},
+									pos:   position{line: 1004, col: 8, offset: 31052},
 									label: "operator",
 									expr: &ruleRefExpr{
-										pos:  position{line: 421, col: 17, offset: 10446},
+										pos:  position{line: 1004, col: 17, offset: 31061},
 										name: "Operator",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 421, col: 26, offset: 10455},
+									pos:  position{line: 1004, col: 26, offset: 31070},
 									name: "EOF",
 								},
 							},
@@ -1314,243 +2758,529 @@ var g = &grammar{
 		},
 		{
 			name: "EqualityExpr",
-			pos:  position{line: 426, col: 1, offset: 10513},
+			pos:  position{line: 1009, col: 1, offset: 31128},
+			expr: &choiceExpr{
+				pos: position{line: 1010, col: 7, offset: 31147},
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 1010, col: 7, offset: 31147},
+						run: (*parser).callonEqualityExpr2,
+						expr: &seqExpr{
+							pos: position{line: 1010, col: 7, offset: 31147},
+							exprs: []interface{}{
+								&zeroOrMoreExpr{
+									pos: position{line: 1010, col: 7, offset: 31147},
+									expr: &ruleRefExpr{
+										pos:  position{line: 1010, col: 7, offset: 31147},
+										name: "_",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 1010, col: 10, offset: 31150},
+									label: "eq",
+									expr: &ruleRefExpr{
+										pos:  position{line: 1010, col: 13, offset: 31153},
+										name: "WordEquality",
+									},
+								},
+								&oneOrMoreExpr{
+									pos: position{line: 1010, col: 26, offset: 31166},
+									expr: &ruleRefExpr{
+										pos:  position{line: 1010, col: 26, offset: 31166},
+										name: "_",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 1014, col: 7, offset: 31222},
+						run: (*parser).callonEqualityExpr10,
+						expr: &seqExpr{
+							pos: position{line: 1014, col: 7, offset: 31222},
+							exprs: []interface{}{
+								&zeroOrMoreExpr{
+									pos: position{line: 1014, col: 7, offset: 31222},
+									expr: &ruleRefExpr{
+										pos:  position{line: 1014, col: 7, offset: 31222},
+										name: "_",
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 1014, col: 10, offset: 31225},
+									label: "eq",
+									expr: &ruleRefExpr{
+										pos:  position{line: 1014, col: 13, offset: 31228},
+										name: "Equality",
+									},
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 1014, col: 22, offset: 31237},
+									expr: &ruleRefExpr{
+										pos:  position{line: 1014, col: 22, offset: 31237},
+										name: "_",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "WordEquality",
+			pos:  position{line: 1019, col: 1, offset: 31288},
 			expr: &actionExpr{
-				pos: position{line: 427, col: 7, offset: 10532},
-				run: (*parser).callonEqualityExpr1,
+				pos: position{line: 1020, col: 7, offset: 31307},
+				run: (*parser).callonWordEquality1,
 				expr: &seqExpr{
-					pos: position{line: 427, col: 7, offset: 10532},
+					pos: position{line: 1020, col: 7, offset: 31307},
 					exprs: []interface{}{
-						&zeroOrMoreExpr{
-							pos: position{line: 427, col: 7, offset: 10532},
-							expr: &ruleRefExpr{
-								pos:  position{line: 427, col: 7, offset: 10532},
-								name: "_",
-							},
-						},
 						&labeledExpr{
-							pos:   position{line: 427, col: 10, offset: 10535},
-							label: "eq",
+							pos:   position{line: 1020, col: 7, offset: 31307},
+							label: "word",
 							expr: &ruleRefExpr{
-								pos:  position{line: 427, col: 13, offset: 10538},
-								name: "Equality",
+								pos:  position{line: 1020, col: 12, offset: 31312},
+								name: "WordOperator",
 							},
 						},
-						&zeroOrMoreExpr{
-							pos: position{line: 427, col: 22, offset: 10547},
-							expr: &ruleRefExpr{
-								pos:  position{line: 427, col: 22, offset: 10547},
-								name: "_",
-							},
+						&andCodeExpr{
+							pos: position{line: 1020, col: 25, offset: 31325},
+							run: (*parser).callonWordEquality5,
 						},
 					},
 				},
 			},
 		},
+		{
+			name: "WordOperator",
+			pos:  position{line: 1029, col: 1, offset: 31495},
+			expr: &actionExpr{
+				pos: position{line: 1030, col: 7, offset: 31514},
+				run: (*parser).callonWordOperator1,
+				expr: &oneOrMoreExpr{
+					pos: position{line: 1030, col: 7, offset: 31514},
+					expr: &charClassMatcher{
+						pos:        position{line: 1030, col: 7, offset: 31514},
+						val:        "[a-zA-Z_]",
+						chars:      []rune{'_'},
+						ranges:     []rune{'a', 'z', 'A', 'Z'},
+						ignoreCase: false,
+						inverted:   false,
+					},
+				},
+			},
+		},
 		{
 			name: "Equality",
-			pos:  position{line: 433, col: 1, offset: 10599},
+			pos:  position{line: 1036, col: 1, offset: 31574},
 			expr: &choiceExpr{
-				pos: position{line: 434, col: 7, offset: 10614},
+				pos: position{line: 1037, col: 7, offset: 31589},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 434, col: 7, offset: 10614},
+						pos: position{line: 1037, col: 7, offset: 31589},
 						run: (*parser).callonEquality2,
 						expr: &litMatcher{
-							pos:        position{line: 434, col: 7, offset: 10614},
+							pos:        position{line: 1037, col: 7, offset: 31589},
+							val:        "??",
+							ignoreCase: false,
+							want:       "\"??\"",
+						},
+					},
+					&actionExpr{
+						pos: position{line: 1038, col: 7, offset: 31623},
+						run: (*parser).callonEquality4,
+						expr: &litMatcher{
+							pos:        position{line: 1038, col: 7, offset: 31623},
 							val:        ">=",
 							ignoreCase: false,
 							want:       "\">=\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 435, col: 7, offset: 10648},
-						run: (*parser).callonEquality4,
+						pos: position{line: 1039, col: 7, offset: 31657},
+						run: (*parser).callonEquality6,
 						expr: &litMatcher{
-							pos:        position{line: 435, col: 7, offset: 10648},
+							pos:        position{line: 1039, col: 7, offset: 31657},
 							val:        ">",
 							ignoreCase: false,
 							want:       "\">\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 436, col: 7, offset: 10682},
-						run: (*parser).callonEquality6,
+						pos: position{line: 1040, col: 7, offset: 31691},
+						run: (*parser).callonEquality8,
 						expr: &litMatcher{
-							pos:        position{line: 436, col: 7, offset: 10682},
+							pos:        position{line: 1040, col: 7, offset: 31691},
 							val:        "<=",
 							ignoreCase: false,
 							want:       "\"<=\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 437, col: 7, offset: 10716},
-						run: (*parser).callonEquality8,
+						pos: position{line: 1041, col: 7, offset: 31725},
+						run: (*parser).callonEquality10,
 						expr: &litMatcher{
-							pos:        position{line: 437, col: 7, offset: 10716},
+							pos:        position{line: 1041, col: 7, offset: 31725},
 							val:        "<",
 							ignoreCase: false,
 							want:       "\"<\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 438, col: 7, offset: 10750},
-						run: (*parser).callonEquality10,
+						pos: position{line: 1042, col: 7, offset: 31759},
+						run: (*parser).callonEquality12,
 						expr: &litMatcher{
-							pos:        position{line: 438, col: 7, offset: 10750},
+							pos:        position{line: 1042, col: 7, offset: 31759},
 							val:        "!=",
 							ignoreCase: false,
 							want:       "\"!=\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 439, col: 7, offset: 10784},
-						run: (*parser).callonEquality12,
+						pos: position{line: 1043, col: 7, offset: 31793},
+						run: (*parser).callonEquality14,
 						expr: &litMatcher{
-							pos:        position{line: 439, col: 7, offset: 10784},
+							pos:        position{line: 1043, col: 7, offset: 31793},
 							val:        "!~*",
 							ignoreCase: false,
 							want:       "\"!~*\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 440, col: 7, offset: 10818},
-						run: (*parser).callonEquality14,
+						pos: position{line: 1044, col: 7, offset: 31827},
+						run: (*parser).callonEquality16,
 						expr: &litMatcher{
-							pos:        position{line: 440, col: 7, offset: 10818},
+							pos:        position{line: 1044, col: 7, offset: 31827},
 							val:        "!~",
 							ignoreCase: false,
 							want:       "\"!~\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 441, col: 7, offset: 10852},
-						run: (*parser).callonEquality16,
+						pos: position{line: 1045, col: 7, offset: 31861},
+						run: (*parser).callonEquality18,
 						expr: &litMatcher{
-							pos:        position{line: 441, col: 7, offset: 10852},
+							pos:        position{line: 1045, col: 7, offset: 31861},
 							val:        "~*",
 							ignoreCase: false,
 							want:       "\"~*\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 442, col: 7, offset: 10886},
-						run: (*parser).callonEquality18,
+						pos: position{line: 1046, col: 7, offset: 31895},
+						run: (*parser).callonEquality20,
 						expr: &litMatcher{
-							pos:        position{line: 442, col: 7, offset: 10886},
+							pos:        position{line: 1046, col: 7, offset: 31895},
 							val:        "~",
 							ignoreCase: false,
 							want:       "\"~\"",
 						},
 					},
-					&litMatcher{
-						pos:        position{line: 443, col: 7, offset: 10920},
-						val:        "gte",
-						ignoreCase: false,
-						want:       "\"gte\"",
+					&actionExpr{
+						pos: position{line: 1047, col: 7, offset: 31929},
+						run: (*parser).callonEquality22,
+						expr: &seqExpr{
+							pos: position{line: 1047, col: 7, offset: 31929},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 1047, col: 7, offset: 31929},
+									val:        "gte",
+									ignoreCase: false,
+									want:       "\"gte\"",
+								},
+								&notExpr{
+									pos: position{line: 1047, col: 13, offset: 31935},
+									expr: &charClassMatcher{
+										pos:        position{line: 1047, col: 14, offset: 31936},
+										val:        "[a-zA-Z_]",
+										chars:      []rune{'_'},
+										ranges:     []rune{'a', 'z', 'A', 'Z'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+							},
+						},
 					},
-					&litMatcher{
-						pos:        position{line: 444, col: 7, offset: 10932},
-						val:        "gt",
-						ignoreCase: false,
-						want:       "\"gt\"",
+					&actionExpr{
+						pos: position{line: 1048, col: 7, offset: 31974},
+						run: (*parser).callonEquality27,
+						expr: &seqExpr{
+							pos: position{line: 1048, col: 7, offset: 31974},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 1048, col: 7, offset: 31974},
+									val:        "gt",
+									ignoreCase: false,
+									want:       "\"gt\"",
+								},
+								&notExpr{
+									pos: position{line: 1048, col: 13, offset: 31980},
+									expr: &charClassMatcher{
+										pos:        position{line: 1048, col: 14, offset: 31981},
+										val:        "[a-zA-Z_]",
+										chars:      []rune{'_'},
+										ranges:     []rune{'a', 'z', 'A', 'Z'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+							},
+						},
 					},
-					&litMatcher{
-						pos:        position{line: 445, col: 7, offset: 10943},
-						val:        "lte",
-						ignoreCase: false,
-						want:       "\"lte\"",
+					&actionExpr{
+						pos: position{line: 1049, col: 7, offset: 32019},
+						run: (*parser).callonEquality32,
+						expr: &seqExpr{
+							pos: position{line: 1049, col: 7, offset: 32019},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 1049, col: 7, offset: 32019},
+									val:        "lte",
+									ignoreCase: false,
+									want:       "\"lte\"",
+								},
+								&notExpr{
+									pos: position{line: 1049, col: 13, offset: 32025},
+									expr: &charClassMatcher{
+										pos:        position{line: 1049, col: 14, offset: 32026},
+										val:        "[a-zA-Z_]",
+										chars:      []rune{'_'},
+										ranges:     []rune{'a', 'z', 'A', 'Z'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+							},
+						},
 					},
-					&litMatcher{
-						pos:        position{line: 446, col: 7, offset: 10955},
-						val:        "lt",
-						ignoreCase: false,
-						want:       "\"lt\"",
+					&actionExpr{
+						pos: position{line: 1050, col: 7, offset: 32064},
+						run: (*parser).callonEquality37,
+						expr: &seqExpr{
+							pos: position{line: 1050, col: 7, offset: 32064},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 1050, col: 7, offset: 32064},
+									val:        "lt",
+									ignoreCase: false,
+									want:       "\"lt\"",
+								},
+								&notExpr{
+									pos: position{line: 1050, col: 13, offset: 32070},
+									expr: &charClassMatcher{
+										pos:        position{line: 1050, col: 14, offset: 32071},
+										val:        "[a-zA-Z_]",
+										chars:      []rune{'_'},
+										ranges:     []rune{'a', 'z', 'A', 'Z'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+							},
+						},
 					},
-					&litMatcher{
-						pos:        position{line: 447, col: 7, offset: 10966},
-						val:        "eq",
-						ignoreCase: false,
-						want:       "\"eq\"",
+					&actionExpr{
+						pos: position{line: 1051, col: 7, offset: 32109},
+						run: (*parser).callonEquality42,
+						expr: &seqExpr{
+							pos: position{line: 1051, col: 7, offset: 32109},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 1051, col: 7, offset: 32109},
+									val:        "eq",
+									ignoreCase: false,
+									want:       "\"eq\"",
+								},
+								&notExpr{
+									pos: position{line: 1051, col: 13, offset: 32115},
+									expr: &charClassMatcher{
+										pos:        position{line: 1051, col: 14, offset: 32116},
+										val:        "[a-zA-Z_]",
+										chars:      []rune{'_'},
+										ranges:     []rune{'a', 'z', 'A', 'Z'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+							},
+						},
 					},
-					&litMatcher{
-						pos:        position{line: 448, col: 7, offset: 10977},
-						val:        "neq",
-						ignoreCase: false,
-						want:       "\"neq\"",
+					&actionExpr{
+						pos: position{line: 1052, col: 7, offset: 32154},
+						run: (*parser).callonEquality47,
+						expr: &seqExpr{
+							pos: position{line: 1052, col: 7, offset: 32154},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 1052, col: 7, offset: 32154},
+									val:        "neq",
+									ignoreCase: false,
+									want:       "\"neq\"",
+								},
+								&notExpr{
+									pos: position{line: 1052, col: 13, offset: 32160},
+									expr: &charClassMatcher{
+										pos:        position{line: 1052, col: 14, offset: 32161},
+										val:        "[a-zA-Z_]",
+										chars:      []rune{'_'},
+										ranges:     []rune{'a', 'z', 'A', 'Z'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 1053, col: 7, offset: 32199},
+						run: (*parser).callonEquality52,
+						expr: &seqExpr{
+							pos: position{line: 1053, col: 7, offset: 32199},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 1053, col: 7, offset: 32199},
+									val:        "contains",
+									ignoreCase: false,
+									want:       "\"contains\"",
+								},
+								&notExpr{
+									pos: position{line: 1053, col: 18, offset: 32210},
+									expr: &charClassMatcher{
+										pos:        position{line: 1053, col: 19, offset: 32211},
+										val:        "[a-zA-Z_]",
+										chars:      []rune{'_'},
+										ranges:     []rune{'a', 'z', 'A', 'Z'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+								&andExpr{
+									pos: position{line: 1053, col: 29, offset: 32221},
+									expr: &seqExpr{
+										pos: position{line: 1053, col: 31, offset: 32223},
+										exprs: []interface{}{
+											&zeroOrMoreExpr{
+												pos: position{line: 1053, col: 31, offset: 32223},
+												expr: &ruleRefExpr{
+													pos:  position{line: 1053, col: 31, offset: 32223},
+													name: "_",
+												},
+											},
+											&notExpr{
+												pos: position{line: 1053, col: 34, offset: 32226},
+												expr: &choiceExpr{
+													pos: position{line: 1053, col: 36, offset: 32228},
+													alternatives: []interface{}{
+														&ruleRefExpr{
+															pos:  position{line: 1053, col: 36, offset: 32228},
+															name: "Fieldname",
+														},
+														&seqExpr{
+															pos: position{line: 1053, col: 48, offset: 32240},
+															exprs: []interface{}{
+																&ruleRefExpr{
+																	pos:  position{line: 1053, col: 48, offset: 32240},
+																	name: "Operator",
+																},
+																&charClassMatcher{
+																	pos:        position{line: 1053, col: 57, offset: 32249},
+																	val:        "[ \\t\\r\\n\\u00A0]",
+																	chars:      []rune{' ', '\t', '\r', '\n', '\u00a0'},
+																	ignoreCase: false,
+																	inverted:   false,
+																},
+															},
+														},
+													},
+												},
+											},
+											&charClassMatcher{
+												pos:        position{line: 1053, col: 74, offset: 32266},
+												val:        "[^ \\t\\r\\n\\u00A0)(]",
+												chars:      []rune{' ', '\t', '\r', '\n', '\u00a0', ')', '('},
+												ignoreCase: false,
+												inverted:   true,
+											},
+										},
+									},
+								},
+							},
+						},
 					},
 				},
 			},
 		},
 		{
 			name: "Operator",
-			pos:  position{line: 450, col: 1, offset: 10984},
+			pos:  position{line: 1055, col: 1, offset: 32314},
 			expr: &choiceExpr{
-				pos: position{line: 451, col: 5, offset: 10997},
+				pos: position{line: 1056, col: 5, offset: 32327},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 451, col: 5, offset: 10997},
+						pos:        position{line: 1056, col: 5, offset: 32327},
 						val:        "OR",
 						ignoreCase: false,
 						want:       "\"OR\"",
 					},
 					&litMatcher{
-						pos:        position{line: 452, col: 5, offset: 11006},
+						pos:        position{line: 1057, col: 5, offset: 32336},
 						val:        "AND",
 						ignoreCase: false,
 						want:       "\"AND\"",
 					},
 					&litMatcher{
-						pos:        position{line: 453, col: 5, offset: 11016},
+						pos:        position{line: 1058, col: 5, offset: 32346},
 						val:        "NOT",
 						ignoreCase: false,
 						want:       "\"NOT\"",
 					},
 					&actionExpr{
-						pos: position{line: 454, col: 5, offset: 11026},
+						pos: position{line: 1059, col: 5, offset: 32356},
 						run: (*parser).callonOperator5,
 						expr: &litMatcher{
-							pos:        position{line: 454, col: 5, offset: 11026},
+							pos:        position{line: 1059, col: 5, offset: 32356},
 							val:        "||",
 							ignoreCase: false,
 							want:       "\"||\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 455, col: 5, offset: 11057},
+						pos: position{line: 1060, col: 5, offset: 32387},
 						run: (*parser).callonOperator7,
 						expr: &litMatcher{
-							pos:        position{line: 455, col: 5, offset: 11057},
+							pos:        position{line: 1060, col: 5, offset: 32387},
 							val:        "&&",
 							ignoreCase: false,
 							want:       "\"&&\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 456, col: 5, offset: 11089},
+						pos: position{line: 1061, col: 5, offset: 32419},
 						run: (*parser).callonOperator9,
 						expr: &litMatcher{
-							pos:        position{line: 456, col: 5, offset: 11089},
+							pos:        position{line: 1061, col: 5, offset: 32419},
 							val:        "and",
 							ignoreCase: false,
 							want:       "\"and\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 457, col: 5, offset: 11121},
+						pos: position{line: 1062, col: 5, offset: 32451},
 						run: (*parser).callonOperator11,
 						expr: &litMatcher{
-							pos:        position{line: 457, col: 5, offset: 11121},
+							pos:        position{line: 1062, col: 5, offset: 32451},
 							val:        "or",
 							ignoreCase: false,
 							want:       "\"or\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 458, col: 5, offset: 11152},
+						pos: position{line: 1063, col: 5, offset: 32482},
 						run: (*parser).callonOperator13,
 						expr: &litMatcher{
-							pos:        position{line: 458, col: 5, offset: 11152},
+							pos:        position{line: 1063, col: 5, offset: 32482},
 							val:        "not",
 							ignoreCase: false,
 							want:       "\"not\"",
@@ -1561,25 +3291,25 @@ var g = &grammar{
 		},
 		{
 			name: "PrefixOperatorExp",
-			pos:  position{line: 460, col: 1, offset: 11181},
+			pos:  position{line: 1065, col: 1, offset: 32511},
 			expr: &actionExpr{
-				pos: position{line: 461, col: 5, offset: 11203},
+				pos: position{line: 1066, col: 5, offset: 32533},
 				run: (*parser).callonPrefixOperatorExp1,
 				expr: &seqExpr{
-					pos: position{line: 461, col: 5, offset: 11203},
+					pos: position{line: 1066, col: 5, offset: 32533},
 					exprs: []interface{}{
 						&zeroOrMoreExpr{
-							pos: position{line: 461, col: 5, offset: 11203},
+							pos: position{line: 1066, col: 5, offset: 32533},
 							expr: &ruleRefExpr{
-								pos:  position{line: 461, col: 5, offset: 11203},
+								pos:  position{line: 1066, col: 5, offset: 32533},
 								name: "_",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 461, col: 8, offset: 11206},
+							pos:   position{line: 1066, col: 8, offset: 32536},
 							label: "operator",
 							expr: &ruleRefExpr{
-								pos:  position{line: 461, col: 17, offset: 11215},
+								pos:  position{line: 1066, col: 17, offset: 32545},
 								name: "PrefixOperator",
 							},
 						},
@@ -1589,18 +3319,18 @@ var g = &grammar{
 		},
 		{
 			name: "PrefixOperator",
-			pos:  position{line: 466, col: 1, offset: 11284},
+			pos:  position{line: 1071, col: 1, offset: 32614},
 			expr: &choiceExpr{
-				pos: position{line: 467, col: 5, offset: 11303},
+				pos: position{line: 1072, col: 5, offset: 32633},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 467, col: 5, offset: 11303},
+						pos:        position{line: 1072, col: 5, offset: 32633},
 						val:        "+",
 						ignoreCase: false,
 						want:       "\"+\"",
 					},
 					&litMatcher{
-						pos:        position{line: 468, col: 5, offset: 11311},
+						pos:        position{line: 1073, col: 5, offset: 32641},
 						val:        "-",
 						ignoreCase: false,
 						want:       "\"-\"",
@@ -1610,9 +3340,9 @@ var g = &grammar{
 		},
 		{
 			name: "EscapedChar",
-			pos:  position{line: 470, col: 1, offset: 11316},
+			pos:  position{line: 1075, col: 1, offset: 32646},
 			expr: &charClassMatcher{
-				pos:        position{line: 470, col: 16, offset: 11331},
+				pos:        position{line: 1075, col: 16, offset: 32661},
 				val:        "[\\x00-\\x1f\"\\\\]",
 				chars:      []rune{'"', '\\'},
 				ranges:     []rune{'\x00', '\x1f'},
@@ -1622,16 +3352,16 @@ var g = &grammar{
 		},
 		{
 			name: "EscapeSequence",
-			pos:  position{line: 472, col: 1, offset: 11347},
+			pos:  position{line: 1077, col: 1, offset: 32677},
 			expr: &choiceExpr{
-				pos: position{line: 472, col: 19, offset: 11365},
+				pos: position{line: 1077, col: 19, offset: 32695},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 472, col: 19, offset: 11365},
+						pos:  position{line: 1077, col: 19, offset: 32695},
 						name: "SingleCharEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 472, col: 38, offset: 11384},
+						pos:  position{line: 1077, col: 38, offset: 32714},
 						name: "UnicodeEscape",
 					},
 				},
@@ -1639,9 +3369,9 @@ var g = &grammar{
 		},
 		{
 			name: "SingleCharEscape",
-			pos:  position{line: 474, col: 1, offset: 11399},
+			pos:  position{line: 1079, col: 1, offset: 32729},
 			expr: &charClassMatcher{
-				pos:        position{line: 474, col: 21, offset: 11419},
+				pos:        position{line: 1079, col: 21, offset: 32749},
 				val:        "[\"\\\\/bfnrt]",
 				chars:      []rune{'"', '\\', '/', 'b', 'f', 'n', 'r', 't'},
 				ignoreCase: false,
@@ -1650,9 +3380,9 @@ var g = &grammar{
 		},
 		{
 			name: "UnicodeEscape",
-			pos:  position{line: 476, col: 1, offset: 11432},
+			pos:  position{line: 1081, col: 1, offset: 32762},
 			expr: &litMatcher{
-				pos:        position{line: 476, col: 18, offset: 11449},
+				pos:        position{line: 1081, col: 18, offset: 32779},
 				val:        "u",
 				ignoreCase: false,
 				want:       "\"u\"",
@@ -1660,28 +3390,60 @@ var g = &grammar{
 		},
 		{
 			name: "Bool",
-			pos:  position{line: 478, col: 1, offset: 11454},
+			pos:  position{line: 1083, col: 1, offset: 32784},
 			expr: &choiceExpr{
-				pos: position{line: 478, col: 9, offset: 11462},
+				pos: position{line: 1083, col: 9, offset: 32792},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 478, col: 9, offset: 11462},
+						pos: position{line: 1083, col: 9, offset: 32792},
 						run: (*parser).callonBool2,
-						expr: &litMatcher{
-							pos:        position{line: 478, col: 9, offset: 11462},
-							val:        "true",
-							ignoreCase: false,
-							want:       "\"true\"",
+						expr: &seqExpr{
+							pos: position{line: 1083, col: 9, offset: 32792},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 1083, col: 9, offset: 32792},
+									val:        "true",
+									ignoreCase: false,
+									want:       "\"true\"",
+								},
+								&notExpr{
+									pos: position{line: 1083, col: 16, offset: 32799},
+									expr: &charClassMatcher{
+										pos:        position{line: 1083, col: 17, offset: 32800},
+										val:        "[a-zA-Z0-9_.]",
+										chars:      []rune{'_', '.'},
+										ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 478, col: 39, offset: 11492},
-						run: (*parser).callonBool4,
-						expr: &litMatcher{
-							pos:        position{line: 478, col: 39, offset: 11492},
-							val:        "false",
-							ignoreCase: false,
-							want:       "\"false\"",
+						pos: position{line: 1083, col: 54, offset: 32837},
+						run: (*parser).callonBool7,
+						expr: &seqExpr{
+							pos: position{line: 1083, col: 54, offset: 32837},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 1083, col: 54, offset: 32837},
+									val:        "false",
+									ignoreCase: false,
+									want:       "\"false\"",
+								},
+								&notExpr{
+									pos: position{line: 1083, col: 62, offset: 32845},
+									expr: &charClassMatcher{
+										pos:        position{line: 1083, col: 63, offset: 32846},
+										val:        "[a-zA-Z0-9_.]",
+										chars:      []rune{'_', '.'},
+										ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
+										ignoreCase: false,
+										inverted:   false,
+									},
+								},
+							},
 						},
 					},
 				},
@@ -1689,26 +3451,93 @@ var g = &grammar{
 		},
 		{
 			name: "Null",
-			pos:  position{line: 480, col: 1, offset: 11523},
+			pos:  position{line: 1085, col: 1, offset: 32883},
 			expr: &actionExpr{
-				pos: position{line: 480, col: 9, offset: 11531},
+				pos: position{line: 1085, col: 9, offset: 32891},
 				run: (*parser).callonNull1,
 				expr: &litMatcher{
-					pos:        position{line: 480, col: 9, offset: 11531},
+					pos:        position{line: 1085, col: 9, offset: 32891},
 					val:        "null",
 					ignoreCase: false,
 					want:       "\"null\"",
 				},
 			},
 		},
+		{
+			name: "TimeAnchor",
+			pos:  position{line: 1087, col: 1, offset: 32919},
+			expr: &actionExpr{
+				pos: position{line: 1087, col: 15, offset: 32933},
+				run: (*parser).callonTimeAnchor1,
+				expr: &seqExpr{
+					pos: position{line: 1087, col: 15, offset: 32933},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 1087, col: 15, offset: 32933},
+							label: "anchor",
+							expr: &choiceExpr{
+								pos: position{line: 1087, col: 23, offset: 32941},
+								alternatives: []interface{}{
+									&litMatcher{
+										pos:        position{line: 1087, col: 23, offset: 32941},
+										val:        "today",
+										ignoreCase: true,
+										want:       "\"today\"i",
+									},
+									&litMatcher{
+										pos:        position{line: 1087, col: 34, offset: 32952},
+										val:        "yesterday",
+										ignoreCase: true,
+										want:       "\"yesterday\"i",
+									},
+									&litMatcher{
+										pos:        position{line: 1087, col: 49, offset: 32967},
+										val:        "now",
+										ignoreCase: true,
+										want:       "\"now\"i",
+									},
+								},
+							},
+						},
+						&notExpr{
+							pos: position{line: 1087, col: 57, offset: 32975},
+							expr: &charClassMatcher{
+								pos:        position{line: 1087, col: 58, offset: 32976},
+								val:        "[a-zA-Z0-9_.]",
+								chars:      []rune{'_', '.'},
+								ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "RangeAnchor",
+			pos:  position{line: 1089, col: 1, offset: 33055},
+			expr: &actionExpr{
+				pos: position{line: 1089, col: 16, offset: 33070},
+				run: (*parser).callonRangeAnchor1,
+				expr: &labeledExpr{
+					pos:   position{line: 1089, col: 16, offset: 33070},
+					label: "anchor",
+					expr: &ruleRefExpr{
+						pos:  position{line: 1089, col: 23, offset: 33077},
+						name: "TimeAnchor",
+					},
+				},
+			},
+		},
 		{
 			name: "WildCard",
-			pos:  position{line: 482, col: 1, offset: 11559},
+			pos:  position{line: 1091, col: 1, offset: 33168},
 			expr: &actionExpr{
-				pos: position{line: 482, col: 13, offset: 11571},
+				pos: position{line: 1091, col: 13, offset: 33180},
 				run: (*parser).callonWildCard1,
 				expr: &litMatcher{
-					pos:        position{line: 482, col: 13, offset: 11571},
+					pos:        position{line: 1091, col: 13, offset: 33180},
 					val:        "*",
 					ignoreCase: false,
 					want:       "\"*\"",
@@ -1717,49 +3546,49 @@ var g = &grammar{
 		},
 		{
 			name: "WildCardExp",
-			pos:  position{line: 484, col: 1, offset: 11596},
+			pos:  position{line: 1093, col: 1, offset: 33205},
 			expr: &choiceExpr{
-				pos: position{line: 486, col: 6, offset: 11619},
+				pos: position{line: 1095, col: 6, offset: 33228},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 486, col: 6, offset: 11619},
+						pos: position{line: 1095, col: 6, offset: 33228},
 						run: (*parser).callonWildCardExp2,
 						expr: &seqExpr{
-							pos: position{line: 486, col: 6, offset: 11619},
+							pos: position{line: 1095, col: 6, offset: 33228},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 486, col: 6, offset: 11619},
+									pos:   position{line: 1095, col: 6, offset: 33228},
 									label: "prefix",
 									expr: &choiceExpr{
-										pos: position{line: 486, col: 14, offset: 11627},
+										pos: position{line: 1095, col: 14, offset: 33236},
 										alternatives: []interface{}{
 											&ruleRefExpr{
-												pos:  position{line: 486, col: 14, offset: 11627},
+												pos:  position{line: 1095, col: 14, offset: 33236},
 												name: "UnquotedTerm",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 486, col: 29, offset: 11642},
+												pos:  position{line: 1095, col: 29, offset: 33251},
 												name: "QuotedTerm",
 											},
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 486, col: 41, offset: 11654},
+									pos:  position{line: 1095, col: 41, offset: 33263},
 									name: "WildCard",
 								},
 								&labeledExpr{
-									pos:   position{line: 486, col: 50, offset: 11663},
+									pos:   position{line: 1095, col: 50, offset: 33272},
 									label: "suffix",
 									expr: &choiceExpr{
-										pos: position{line: 486, col: 58, offset: 11671},
+										pos: position{line: 1095, col: 58, offset: 33280},
 										alternatives: []interface{}{
 											&ruleRefExpr{
-												pos:  position{line: 486, col: 58, offset: 11671},
+												pos:  position{line: 1095, col: 58, offset: 33280},
 												name: "UnquotedTerm",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 486, col: 73, offset: 11686},
+												pos:  position{line: 1095, col: 73, offset: 33295},
 												name: "QuotedTerm",
 											},
 										},
@@ -1769,91 +3598,91 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 487, col: 7, offset: 11791},
+						pos: position{line: 1096, col: 7, offset: 33400},
 						run: (*parser).callonWildCardExp13,
 						expr: &seqExpr{
-							pos: position{line: 487, col: 7, offset: 11791},
+							pos: position{line: 1096, col: 7, offset: 33400},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 487, col: 7, offset: 11791},
+									pos:   position{line: 1096, col: 7, offset: 33400},
 									label: "term",
 									expr: &choiceExpr{
-										pos: position{line: 487, col: 13, offset: 11797},
+										pos: position{line: 1096, col: 13, offset: 33406},
 										alternatives: []interface{}{
 											&ruleRefExpr{
-												pos:  position{line: 487, col: 13, offset: 11797},
+												pos:  position{line: 1096, col: 13, offset: 33406},
 												name: "UnquotedTerm",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 487, col: 28, offset: 11812},
+												pos:  position{line: 1096, col: 28, offset: 33421},
 												name: "QuotedTerm",
 											},
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 487, col: 40, offset: 11824},
+									pos:  position{line: 1096, col: 40, offset: 33433},
 									name: "WildCard",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 488, col: 7, offset: 11896},
+						pos: position{line: 1097, col: 7, offset: 33505},
 						run: (*parser).callonWildCardExp20,
 						expr: &seqExpr{
-							pos: position{line: 488, col: 7, offset: 11896},
+							pos: position{line: 1097, col: 7, offset: 33505},
 							exprs: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 488, col: 7, offset: 11896},
+									pos:  position{line: 1097, col: 7, offset: 33505},
 									name: "WildCard",
 								},
 								&labeledExpr{
-									pos:   position{line: 488, col: 16, offset: 11905},
+									pos:   position{line: 1097, col: 16, offset: 33514},
 									label: "term",
 									expr: &choiceExpr{
-										pos: position{line: 488, col: 22, offset: 11911},
+										pos: position{line: 1097, col: 22, offset: 33520},
 										alternatives: []interface{}{
 											&ruleRefExpr{
-												pos:  position{line: 488, col: 22, offset: 11911},
+												pos:  position{line: 1097, col: 22, offset: 33520},
 												name: "UnquotedTerm",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 488, col: 37, offset: 11926},
+												pos:  position{line: 1097, col: 37, offset: 33535},
 												name: "QuotedTerm",
 											},
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 488, col: 49, offset: 11938},
+									pos:  position{line: 1097, col: 49, offset: 33547},
 									name: "WildCard",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 489, col: 7, offset: 12007},
+						pos: position{line: 1098, col: 7, offset: 33616},
 						run: (*parser).callonWildCardExp28,
 						expr: &seqExpr{
-							pos: position{line: 489, col: 7, offset: 12007},
+							pos: position{line: 1098, col: 7, offset: 33616},
 							exprs: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 489, col: 7, offset: 12007},
+									pos:  position{line: 1098, col: 7, offset: 33616},
 									name: "WildCard",
 								},
 								&labeledExpr{
-									pos:   position{line: 489, col: 16, offset: 12016},
+									pos:   position{line: 1098, col: 16, offset: 33625},
 									label: "term",
 									expr: &choiceExpr{
-										pos: position{line: 489, col: 22, offset: 12022},
+										pos: position{line: 1098, col: 22, offset: 33631},
 										alternatives: []interface{}{
 											&ruleRefExpr{
-												pos:  position{line: 489, col: 22, offset: 12022},
+												pos:  position{line: 1098, col: 22, offset: 33631},
 												name: "UnquotedTerm",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 489, col: 37, offset: 12037},
+												pos:  position{line: 1098, col: 37, offset: 33646},
 												name: "QuotedTerm",
 											},
 										},
@@ -1863,10 +3692,10 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 490, col: 7, offset: 12112},
+						pos: position{line: 1099, col: 7, offset: 33721},
 						run: (*parser).callonWildCardExp35,
 						expr: &ruleRefExpr{
-							pos:  position{line: 490, col: 7, offset: 12112},
+							pos:  position{line: 1099, col: 7, offset: 33721},
 							name: "WildCard",
 						},
 					},
@@ -1876,25 +3705,144 @@ var g = &grammar{
 		{
 			name:        "_",
 			displayName: "\"whitespace\"",
-			pos:         position{line: 492, col: 1, offset: 12155},
+			pos:         position{line: 1101, col: 1, offset: 33764},
 			expr: &oneOrMoreExpr{
-				pos: position{line: 492, col: 19, offset: 12173},
-				expr: &charClassMatcher{
-					pos:        position{line: 492, col: 19, offset: 12173},
-					val:        "[ \\t\\r\\n]",
-					chars:      []rune{' ', '\t', '\r', '\n'},
-					ignoreCase: false,
-					inverted:   false,
+				pos: position{line: 1101, col: 19, offset: 33782},
+				expr: &choiceExpr{
+					pos: position{line: 1101, col: 20, offset: 33783},
+					alternatives: []interface{}{
+						&seqExpr{
+							pos: position{line: 1101, col: 20, offset: 33783},
+							exprs: []interface{}{
+								&oneOrMoreExpr{
+									pos: position{line: 1101, col: 20, offset: 33783},
+									expr: &ruleRefExpr{
+										pos:  position{line: 1101, col: 20, offset: 33783},
+										name: "Space",
+									},
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 1101, col: 27, offset: 33790},
+									expr: &ruleRefExpr{
+										pos:  position{line: 1101, col: 27, offset: 33790},
+										name: "LineComment",
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 1101, col: 42, offset: 33805},
+							name: "BlockComment",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:        "ValueSpace",
+			displayName: "\"whitespace\"",
+			pos:         position{line: 1104, col: 1, offset: 33938},
+			expr: &zeroOrMoreExpr{
+				pos: position{line: 1104, col: 28, offset: 33965},
+				expr: &choiceExpr{
+					pos: position{line: 1104, col: 29, offset: 33966},
+					alternatives: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 1104, col: 29, offset: 33966},
+							name: "Space",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 1104, col: 37, offset: 33974},
+							name: "BlockComment",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Space",
+			pos:  position{line: 1106, col: 1, offset: 33990},
+			expr: &charClassMatcher{
+				pos:        position{line: 1106, col: 10, offset: 33999},
+				val:        "[ \\t\\r\\n\\u00A0]",
+				chars:      []rune{' ', '\t', '\r', '\n', '\u00a0'},
+				ignoreCase: false,
+				inverted:   false,
+			},
+		},
+		{
+			name: "BlockComment",
+			pos:  position{line: 1108, col: 1, offset: 34016},
+			expr: &seqExpr{
+				pos: position{line: 1108, col: 17, offset: 34032},
+				exprs: []interface{}{
+					&litMatcher{
+						pos:        position{line: 1108, col: 17, offset: 34032},
+						val:        "/*",
+						ignoreCase: false,
+						want:       "\"/*\"",
+					},
+					&zeroOrMoreExpr{
+						pos: position{line: 1108, col: 22, offset: 34037},
+						expr: &seqExpr{
+							pos: position{line: 1108, col: 23, offset: 34038},
+							exprs: []interface{}{
+								&notExpr{
+									pos: position{line: 1108, col: 23, offset: 34038},
+									expr: &litMatcher{
+										pos:        position{line: 1108, col: 24, offset: 34039},
+										val:        "*/",
+										ignoreCase: false,
+										want:       "\"*/\"",
+									},
+								},
+								&anyMatcher{
+									line: 1108, col: 29, offset: 34044,
+								},
+							},
+						},
+					},
+					&litMatcher{
+						pos:        position{line: 1108, col: 33, offset: 34048},
+						val:        "*/",
+						ignoreCase: false,
+						want:       "\"*/\"",
+					},
+				},
+			},
+		},
+		{
+			name: "LineComment",
+			pos:  position{line: 1110, col: 1, offset: 34054},
+			expr: &seqExpr{
+				pos: position{line: 1110, col: 16, offset: 34069},
+				exprs: []interface{}{
+					&litMatcher{
+						pos:        position{line: 1110, col: 16, offset: 34069},
+						val:        "//",
+						ignoreCase: false,
+						want:       "\"//\"",
+					},
+					&zeroOrMoreExpr{
+						pos: position{line: 1110, col: 21, offset: 34074},
+						expr: &charClassMatcher{
+							pos:        position{line: 1110, col: 21, offset: 34074},
+							val:        "[^\\r\\n]",
+							chars:      []rune{'\r', '\n'},
+							ignoreCase: false,
+							inverted:   true,
+						},
+					},
 				},
 			},
 		},
 		{
 			name: "EOF",
-			pos:  position{line: 494, col: 1, offset: 12185},
+			pos:  position{line: 1112, col: 1, offset: 34084},
 			expr: &notExpr{
-				pos: position{line: 494, col: 8, offset: 12192},
+				pos: position{line: 1112, col: 8, offset: 34091},
 				expr: &anyMatcher{
-					line: 494, col: 9, offset: 12193,
+					line: 1112, col: 9, offset: 34092,
 				},
 			},
 		},
@@ -1902,7 +3850,20 @@ var g = &grammar{
 }
 
 func (c *current) onStart2(node interface{}) (interface{}, error) {
-	return toFlatSlice(toIfaceSlice(node)), nil
+	n := castFields(toFlatSlice(toIfaceSlice(node)))
+	if field, _ := c.globalStore["defaultField"].(string); field != "" {
+		n = updateFieldName(n, field)
+	}
+	if err := requireField(c, n); err != nil {
+		return nil, err
+	}
+	if err := maxValueLength(c, n); err != nil {
+		return nil, err
+	}
+	if err := maxFields(c, n); err != nil {
+		return nil, err
+	}
+	return n, nil
 
 }
 
@@ -1912,191 +3873,347 @@ func (p *parser) callonStart2() (interface{}, error) {
 	return p.cur.onStart2(stack["node"])
 }
 
-func (c *current) onStart9() (interface{}, error) {
+func (c *current) onStart13() (interface{}, error) {
 	return nil, errors.New("invalid query")
 
 }
 
-func (p *parser) callonStart9() (interface{}, error) {
+func (p *parser) callonStart13() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onStart9()
+	return p.cur.onStart13()
 }
 
-func (c *current) onStart12() (interface{}, error) {
+func (c *current) onStart16() (interface{}, error) {
 	return nil, errors.New("invalid query")
 
 }
 
-func (p *parser) callonStart12() (interface{}, error) {
+func (p *parser) callonStart16() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onStart16()
+}
+
+func (c *current) onNode2(operator interface{}) (interface{}, error) {
+	return BooleanExpression{
+		Op: toIfaceStr(operator),
+	}, nil
+
+}
+
+func (p *parser) callonNode2() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode2(stack["operator"])
+}
+
+func (c *current) onNode7(operator, right interface{}) (interface{}, error) {
+	return right, nil
+
+}
+
+func (p *parser) callonNode7() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode7(stack["operator"], stack["right"])
+}
+
+func (c *current) onNode15(left, op, right interface{}) (interface{}, error) {
+	operator := strings.TrimSpace(toIfaceStr(op))
+	if operator == "" {
+		operator = "IMPLICIT"
+	}
+	ex := BooleanExpression{
+		Op: operator,
+	}
+	var args []interface{}
+
+	if l, ok := left.([]interface{}); ok {
+		args = append(args, l...)
+	} else {
+		args = append(args, left)
+	}
+	for _, s := range toIfaceSlice(right) {
+		if l, ok := s.([]interface{}); ok {
+			args = append(args, l...)
+		} else {
+			args = append(args, s)
+		}
+	}
+	ex.Args = args
+	return ex, nil
+
+}
+
+func (p *parser) callonNode15() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode15(stack["left"], stack["op"], stack["right"])
+}
+
+func (c *current) onNode25(ex interface{}) (interface{}, error) {
+	return ex, nil
+
+}
+
+func (p *parser) callonNode25() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode25(stack["ex"])
+}
+
+func (c *current) onGroupExp2(exp interface{}) (interface{}, error) {
+	return negate(exp), nil
+
+}
+
+func (p *parser) callonGroupExp2() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onGroupExp2(stack["exp"])
+}
+
+func (c *current) onGroupExp7(prefix, exp interface{}) (interface{}, error) {
+	if toIfaceStr(prefix) == "-" {
+		return negate(exp), nil
+	}
+	if t, ok := exp.(TermQuery); ok {
+		t.Prefix = "+"
+		return t, nil
+	}
+	return exp, nil
+
+}
+
+func (p *parser) callonGroupExp7() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onGroupExp7(stack["prefix"], stack["exp"])
+}
+
+func (c *current) onGroupExp25(exp interface{}) (interface{}, error) {
+	return exp, nil
+
+}
+
+func (p *parser) callonGroupExp25() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onGroupExp25(stack["exp"])
+}
+
+func (c *current) onParenExp1(node interface{}) (interface{}, error) {
+	if n, ok := node.([]interface{}); ok && len(n) == 1 {
+		return n[0], nil
+	}
+	return node, nil
+
+}
+
+func (p *parser) callonParenExp1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onParenExp1(stack["node"])
+}
+
+func (c *current) onFieldExp2(fieldname, quantifier, arr interface{}) (interface{}, error) {
+	return TermQuery{
+		Term:   toIfaceStr(fieldname),
+		Value:  arr,
+		Prefix: "",
+		Op:     strings.ToLower(toIfaceStr(quantifier)),
+	}, nil
+
+}
+
+func (p *parser) callonFieldExp2() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFieldExp2(stack["fieldname"], stack["quantifier"], stack["arr"])
+}
+
+func (c *current) onFieldExp16(fieldname, arr interface{}) (interface{}, error) {
+	return TermQuery{
+		Term:   toIfaceStr(fieldname),
+		Value:  arr,
+		Prefix: "",
+		Op:     "in",
+	}, nil
+
+}
+
+func (p *parser) callonFieldExp16() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFieldExp16(stack["fieldname"], stack["arr"])
+}
+
+func (c *current) onFieldExp24(fieldname, rangeValue interface{}) (interface{}, error) {
+	r, ok := rangeValue.(RangeQuery)
+	if !ok {
+		return nil, errors.New("invalid range")
+	}
+	r.Term = toIfaceStr(fieldname)
+	return r, nil
+
+}
+
+func (p *parser) callonFieldExp24() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onStart12()
+	return p.cur.onFieldExp24(stack["fieldname"], stack["rangeValue"])
 }
 
-func (c *current) onNode2(operator interface{}) (interface{}, error) {
-	return BooleanExpression{
-		Op: toIfaceStr(operator),
-	}, nil
+func (c *current) onFieldExp32(fieldname, rangeValue interface{}) (interface{}, error) {
+	return updateFieldName(rangeValue, toIfaceStr(fieldname)), nil
 
 }
 
-func (p *parser) callonNode2() (interface{}, error) {
+func (p *parser) callonFieldExp32() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode2(stack["operator"])
+	return p.cur.onFieldExp32(stack["fieldname"], stack["rangeValue"])
 }
 
-func (c *current) onNode7(operator, right interface{}) (interface{}, error) {
-	return right, nil
+func (c *current) onFieldExp40(fieldname, node interface{}) (interface{}, error) {
+	field := toIfaceStr(fieldname)
+	if n, ok := node.(TermQuery); ok {
+		n.Term = field
+		return n.Query(), nil
+	}
+	return combineRanges(updateFieldName(node, field)), nil
 
 }
 
-func (p *parser) callonNode7() (interface{}, error) {
+func (p *parser) callonFieldExp40() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode7(stack["operator"], stack["right"])
+	return p.cur.onFieldExp40(stack["fieldname"], stack["node"])
 }
 
-func (c *current) onNode13(left, op, right interface{}) (interface{}, error) {
-	operator := strings.TrimSpace(toIfaceStr(op))
-	if operator == "" {
-		operator = "IMPLICIT"
-	}
-	ex := BooleanExpression{
-		Op: operator,
-	}
-	var args []interface{}
-
-	if l, ok := left.([]interface{}); ok {
-		args = append(args, l...)
-	} else {
-		args = append(args, left)
+func (c *current) onFieldExp47(fieldname, kind, eq, value interface{}) (interface{}, error) {
+	v, err := coerceValue(toIfaceStr(kind), toIfaceStr(value), decimalComma(c))
+	if err != nil {
+		return nil, err
 	}
-	for _, s := range toIfaceSlice(right) {
-		if l, ok := s.([]interface{}); ok {
-			args = append(args, l...)
-		} else {
-			args = append(args, s)
-		}
+	t := TermQuery{
+		Term:  toIfaceStr(fieldname),
+		Value: v,
+		Op:    toIfaceStr(eq),
 	}
-	ex.Args = args
-	return ex, nil
+	return t.Query(), nil
 
 }
 
-func (p *parser) callonNode13() (interface{}, error) {
+func (p *parser) callonFieldExp47() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode13(stack["left"], stack["op"], stack["right"])
+	return p.cur.onFieldExp47(stack["fieldname"], stack["kind"], stack["eq"], stack["value"])
 }
 
-func (c *current) onNode23(ex interface{}) (interface{}, error) {
-	return ex, nil
+func (c *current) onFieldExp61(fieldname, term interface{}) (interface{}, error) {
+	t := term.(TermQuery)
+	t.Term = toIfaceStr(fieldname)
+	t.Op = "??"
+	return t, nil
 
 }
 
-func (p *parser) callonNode23() (interface{}, error) {
+func (p *parser) callonFieldExp61() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode23(stack["ex"])
+	return p.cur.onFieldExp61(stack["fieldname"], stack["term"])
 }
 
-func (c *current) onGroupExp2(exp interface{}) (interface{}, error) {
-	return exp, nil
+func (c *current) onFieldExp74(fieldname, value interface{}) (interface{}, error) {
+	return TermQuery{
+		Term:  toIfaceStr(fieldname),
+		Value: value,
+	}, nil
 
 }
 
-func (p *parser) callonGroupExp2() (interface{}, error) {
+func (p *parser) callonFieldExp74() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onGroupExp2(stack["exp"])
+	return p.cur.onFieldExp74(stack["fieldname"], stack["value"])
 }
 
-func (c *current) onParenExp1(node interface{}) (interface{}, error) {
-	if n, ok := node.([]interface{}); ok && len(n) == 1 {
-		return n[0], nil
-	}
-	return node, nil
+func (c *current) onFieldExp83(fieldname, term interface{}) (interface{}, error) {
+	t := term.(TermQuery)
+	t.Term = toIfaceStr(fieldname)
+	return t.Query(), nil
 
 }
 
-func (p *parser) callonParenExp1() (interface{}, error) {
+func (p *parser) callonFieldExp83() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onParenExp1(stack["node"])
+	return p.cur.onFieldExp83(stack["fieldname"], stack["term"])
 }
 
-func (c *current) onFieldExp2(fieldname, arr interface{}) (interface{}, error) {
-	return TermQuery{
-		Term:   toIfaceStr(fieldname),
-		Value:  arr,
-		Prefix: "",
-		Op:     "in",
-	}, nil
+func (c *current) onFieldname2(fieldname, kind interface{}) (interface{}, error) {
+	cast := toIfaceStr(kind)
+	if !CastTypes[cast] {
+		return nil, fmt.Errorf("%w: `%s` is not a supported type for field `%s`", ErrInvalidCast, cast, toIfaceStr(fieldname))
+	}
+	return toIfaceStr(fieldname) + castSeparator + cast, nil
 
 }
 
-func (p *parser) callonFieldExp2() (interface{}, error) {
+func (p *parser) callonFieldname2() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onFieldExp2(stack["fieldname"], stack["arr"])
+	return p.cur.onFieldname2(stack["fieldname"], stack["kind"])
 }
 
-func (c *current) onFieldExp11(fieldname, rangeValue interface{}) (interface{}, error) {
-	r, ok := rangeValue.(RangeQuery)
-	if !ok {
-		return nil, errors.New("invalid range")
+func (c *current) onFieldname12(fieldname interface{}) (interface{}, error) {
+	// the elasticsearch `_all` field searches the default field
+	if fieldname == "_all" {
+		return "", nil
 	}
-	r.Term = toIfaceStr(fieldname)
-	return r, nil
+	return fieldname, nil
 
 }
 
-func (p *parser) callonFieldExp11() (interface{}, error) {
+func (p *parser) callonFieldname12() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onFieldExp11(stack["fieldname"], stack["rangeValue"])
+	return p.cur.onFieldname12(stack["fieldname"])
 }
 
-func (c *current) onFieldExp20(fieldname, node interface{}) (interface{}, error) {
-	field := toIfaceStr(fieldname)
-	if n, ok := node.(TermQuery); ok {
-		n.Term = field
-		return n.Query(), nil
-	}
-	return updateFieldName(node, field), nil
+func (c *current) onCastType1() (interface{}, error) {
+	return strings.ToLower(string(c.text)), nil
 
 }
 
-func (p *parser) callonFieldExp20() (interface{}, error) {
+func (p *parser) callonCastType1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onFieldExp20(stack["fieldname"], stack["node"])
+	return p.cur.onCastType1()
 }
 
-func (c *current) onFieldExp28(fieldname, term interface{}) (interface{}, error) {
-	t := term.(TermQuery)
-	t.Term = toIfaceStr(fieldname)
-	return t.Query(), nil
+func (c *current) onTypeAnnotation1(kind interface{}) (interface{}, error) {
+	return toIfaceStr(kind), nil
 
 }
 
-func (p *parser) callonFieldExp28() (interface{}, error) {
+func (p *parser) callonTypeAnnotation1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onFieldExp28(stack["fieldname"], stack["term"])
+	return p.cur.onTypeAnnotation1(stack["kind"])
 }
 
-func (c *current) onFieldname1(fieldname interface{}) (interface{}, error) {
-	return fieldname, nil
+func (c *current) onTypedValue3() (interface{}, error) {
+	return string(c.text), nil
 
 }
 
-func (p *parser) callonFieldname1() (interface{}, error) {
+func (p *parser) callonTypedValue3() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onFieldname1(stack["fieldname"])
+	return p.cur.onTypedValue3()
 }
 
 func (c *current) onTerm2(eq, term interface{}) (interface{}, error) {
@@ -2113,7 +4230,21 @@ func (p *parser) callonTerm2() (interface{}, error) {
 	return p.cur.onTerm2(stack["eq"], stack["term"])
 }
 
-func (c *current) onTerm11(eq, op, term interface{}) (interface{}, error) {
+func (c *current) onTerm10(eq, term interface{}) (interface{}, error) {
+	return TermQuery{
+		Value: term,
+		Op:    toIfaceStr(eq),
+	}, nil
+
+}
+
+func (p *parser) callonTerm10() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onTerm10(stack["eq"], stack["term"])
+}
+
+func (c *current) onTerm22(eq, op, term interface{}) (interface{}, error) {
 	return TermQuery{
 		Value:  term,
 		Prefix: toIfaceStr(op),
@@ -2122,10 +4253,10 @@ func (c *current) onTerm11(eq, op, term interface{}) (interface{}, error) {
 
 }
 
-func (p *parser) callonTerm11() (interface{}, error) {
+func (p *parser) callonTerm22() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onTerm11(stack["eq"], stack["op"], stack["term"])
+	return p.cur.onTerm22(stack["eq"], stack["op"], stack["term"])
 }
 
 func (c *current) onUnquotedTerm1(term interface{}) (interface{}, error) {
@@ -2139,6 +4270,17 @@ func (p *parser) callonUnquotedTerm1() (interface{}, error) {
 	return p.cur.onUnquotedTerm1(stack["term"])
 }
 
+func (c *current) onColonTerm1() (interface{}, error) {
+	return string(c.text), nil
+
+}
+
+func (p *parser) callonColonTerm1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onColonTerm1()
+}
+
 func (c *current) onQuotedTerm1() (interface{}, error) {
 	c.text = bytes.Replace(c.text, []byte(`\/`), []byte(`/`), -1)
 	return strconv.Unquote(string(c.text))
@@ -2181,8 +4323,34 @@ func (p *parser) callonArrayExp1() (interface{}, error) {
 	return p.cur.onArrayExp1(stack["vals"])
 }
 
+func (c *current) onDecimalCommaExp3() (bool, error) {
+	return decimalComma(c), nil
+}
+
+func (p *parser) callonDecimalCommaExp3() (bool, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDecimalCommaExp3()
+}
+
+func (c *current) onDecimalCommaExp1() (interface{}, error) {
+	return strconv.ParseFloat(strings.Replace(string(c.text), ",", ".", 1), 64)
+
+}
+
+func (p *parser) callonDecimalCommaExp1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDecimalCommaExp1()
+}
+
 func (c *current) onDecimalExp1() (interface{}, error) {
-	return strconv.ParseFloat(strings.TrimSpace(toIfaceStr(c.text)), 64)
+	// a value out of the float range such as `12e4567` is kept as the unquoted term
+	value, err := strconv.ParseFloat(string(c.text), 64)
+	if err != nil {
+		return string(c.text), nil
+	}
+	return value, nil
 
 }
 
@@ -2203,7 +4371,33 @@ func (p *parser) callonIntExp1() (interface{}, error) {
 	return p.cur.onIntExp1()
 }
 
+func (c *current) onByteSizeExp1(size, unit interface{}) (interface{}, error) {
+	base := 1024
+	if b, ok := c.globalStore["byteSizeBase"].(int); ok && b > 0 {
+		base = b
+	}
+	var value float64
+	switch v := size.(type) {
+	case int:
+		value = float64(v)
+	case float64:
+		value = v
+	default:
+		return string(c.text), nil
+	}
+	power := byteSizeUnits[strings.ToLower(toIfaceStr(unit))]
+	return int(math.Round(value * math.Pow(float64(base), power))), nil
+
+}
+
+func (p *parser) callonByteSizeExp1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onByteSizeExp1(stack["size"], stack["unit"])
+}
+
 func (c *current) onRangeOperatorExp2(termMin, termMax interface{}) (interface{}, error) {
+	termMin, termMax = rangeBounds(termMin, termMax)
 	return RangeQuery{
 		Min:       termMin,
 		Max:       termMax,
@@ -2218,7 +4412,8 @@ func (p *parser) callonRangeOperatorExp2() (interface{}, error) {
 	return p.cur.onRangeOperatorExp2(stack["termMin"], stack["termMax"])
 }
 
-func (c *current) onRangeOperatorExp25(termMin, termMax interface{}) (interface{}, error) {
+func (c *current) onRangeOperatorExp31(termMin, termMax interface{}) (interface{}, error) {
+	termMin, termMax = rangeBounds(termMin, termMax)
 	return RangeQuery{
 		Min:       termMin,
 		Max:       termMax,
@@ -2227,10 +4422,32 @@ func (c *current) onRangeOperatorExp25(termMin, termMax interface{}) (interface{
 
 }
 
-func (p *parser) callonRangeOperatorExp25() (interface{}, error) {
+func (p *parser) callonRangeOperatorExp31() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onRangeOperatorExp31(stack["termMin"], stack["termMax"])
+}
+
+func (c *current) onDotRangeExp2(minOp, min, maxOp, max interface{}) (interface{}, error) {
+	return dotRange(minOp == nil, min, maxOp == nil, max), nil
+
+}
+
+func (p *parser) callonDotRangeExp2() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onRangeOperatorExp25(stack["termMin"], stack["termMax"])
+	return p.cur.onDotRangeExp2(stack["minOp"], stack["min"], stack["maxOp"], stack["max"])
+}
+
+func (c *current) onDotRangeExp18(maxOp, max interface{}) (interface{}, error) {
+	return dotRange(true, nil, maxOp == nil, max), nil
+
+}
+
+func (p *parser) callonDotRangeExp18() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDotRangeExp18(stack["maxOp"], stack["max"])
 }
 
 func (c *current) onOperatorExp2(operator interface{}) (interface{}, error) {
@@ -2255,19 +4472,65 @@ func (p *parser) callonOperatorExp10() (interface{}, error) {
 	return p.cur.onOperatorExp10(stack["operator"])
 }
 
-func (c *current) onEqualityExpr1(eq interface{}) (interface{}, error) {
+func (c *current) onEqualityExpr2(eq interface{}) (interface{}, error) {
+	return toIfaceStr(eq), nil
+
+}
+
+func (p *parser) callonEqualityExpr2() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEqualityExpr2(stack["eq"])
+}
+
+func (c *current) onEqualityExpr10(eq interface{}) (interface{}, error) {
 	return toIfaceStr(eq), nil
 
 }
 
-func (p *parser) callonEqualityExpr1() (interface{}, error) {
+func (p *parser) callonEqualityExpr10() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEqualityExpr10(stack["eq"])
+}
+
+func (c *current) onWordEquality5(word interface{}) (bool, error) {
+	_, ok := wordOperator(c, toIfaceStr(word))
+	return ok, nil
+
+}
+
+func (p *parser) callonWordEquality5() (bool, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onWordEquality5(stack["word"])
+}
+
+func (c *current) onWordEquality1(word interface{}) (interface{}, error) {
+	op, _ := wordOperator(c, toIfaceStr(word))
+	return op, nil
+
+}
+
+func (p *parser) callonWordEquality1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onWordEquality1(stack["word"])
+}
+
+func (c *current) onWordOperator1() (interface{}, error) {
+	return string(c.text), nil
+
+}
+
+func (p *parser) callonWordOperator1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onEqualityExpr1(stack["eq"])
+	return p.cur.onWordOperator1()
 }
 
 func (c *current) onEquality2() (interface{}, error) {
-	return "gte", nil
+	return "??", nil
 }
 
 func (p *parser) callonEquality2() (interface{}, error) {
@@ -2277,7 +4540,7 @@ func (p *parser) callonEquality2() (interface{}, error) {
 }
 
 func (c *current) onEquality4() (interface{}, error) {
-	return "gt", nil
+	return "gte", nil
 }
 
 func (p *parser) callonEquality4() (interface{}, error) {
@@ -2287,7 +4550,7 @@ func (p *parser) callonEquality4() (interface{}, error) {
 }
 
 func (c *current) onEquality6() (interface{}, error) {
-	return "lte", nil
+	return "gt", nil
 }
 
 func (p *parser) callonEquality6() (interface{}, error) {
@@ -2297,7 +4560,7 @@ func (p *parser) callonEquality6() (interface{}, error) {
 }
 
 func (c *current) onEquality8() (interface{}, error) {
-	return "lt", nil
+	return "lte", nil
 }
 
 func (p *parser) callonEquality8() (interface{}, error) {
@@ -2307,7 +4570,7 @@ func (p *parser) callonEquality8() (interface{}, error) {
 }
 
 func (c *current) onEquality10() (interface{}, error) {
-	return "neq", nil
+	return "lt", nil
 }
 
 func (p *parser) callonEquality10() (interface{}, error) {
@@ -2317,7 +4580,7 @@ func (p *parser) callonEquality10() (interface{}, error) {
 }
 
 func (c *current) onEquality12() (interface{}, error) {
-	return "!~*", nil
+	return "neq", nil
 }
 
 func (p *parser) callonEquality12() (interface{}, error) {
@@ -2327,7 +4590,7 @@ func (p *parser) callonEquality12() (interface{}, error) {
 }
 
 func (c *current) onEquality14() (interface{}, error) {
-	return "!~", nil
+	return "!~*", nil
 }
 
 func (p *parser) callonEquality14() (interface{}, error) {
@@ -2337,7 +4600,7 @@ func (p *parser) callonEquality14() (interface{}, error) {
 }
 
 func (c *current) onEquality16() (interface{}, error) {
-	return "~*", nil
+	return "!~", nil
 }
 
 func (p *parser) callonEquality16() (interface{}, error) {
@@ -2347,7 +4610,7 @@ func (p *parser) callonEquality16() (interface{}, error) {
 }
 
 func (c *current) onEquality18() (interface{}, error) {
-	return "~", nil
+	return "~*", nil
 }
 
 func (p *parser) callonEquality18() (interface{}, error) {
@@ -2356,6 +4619,86 @@ func (p *parser) callonEquality18() (interface{}, error) {
 	return p.cur.onEquality18()
 }
 
+func (c *current) onEquality20() (interface{}, error) {
+	return "~", nil
+}
+
+func (p *parser) callonEquality20() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEquality20()
+}
+
+func (c *current) onEquality22() (interface{}, error) {
+	return "gte", nil
+}
+
+func (p *parser) callonEquality22() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEquality22()
+}
+
+func (c *current) onEquality27() (interface{}, error) {
+	return "gt", nil
+}
+
+func (p *parser) callonEquality27() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEquality27()
+}
+
+func (c *current) onEquality32() (interface{}, error) {
+	return "lte", nil
+}
+
+func (p *parser) callonEquality32() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEquality32()
+}
+
+func (c *current) onEquality37() (interface{}, error) {
+	return "lt", nil
+}
+
+func (p *parser) callonEquality37() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEquality37()
+}
+
+func (c *current) onEquality42() (interface{}, error) {
+	return "eq", nil
+}
+
+func (p *parser) callonEquality42() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEquality42()
+}
+
+func (c *current) onEquality47() (interface{}, error) {
+	return "neq", nil
+}
+
+func (p *parser) callonEquality47() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEquality47()
+}
+
+func (c *current) onEquality52() (interface{}, error) {
+	return "contains", nil
+}
+
+func (p *parser) callonEquality52() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEquality52()
+}
+
 func (c *current) onOperator5() (interface{}, error) {
 	return "OR", nil
 }
@@ -2427,14 +4770,14 @@ func (p *parser) callonBool2() (interface{}, error) {
 	return p.cur.onBool2()
 }
 
-func (c *current) onBool4() (interface{}, error) {
+func (c *current) onBool7() (interface{}, error) {
 	return false, nil
 }
 
-func (p *parser) callonBool4() (interface{}, error) {
+func (p *parser) callonBool7() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onBool4()
+	return p.cur.onBool7()
 }
 
 func (c *current) onNull1() (interface{}, error) {
@@ -2447,6 +4790,26 @@ func (p *parser) callonNull1() (interface{}, error) {
 	return p.cur.onNull1()
 }
 
+func (c *current) onTimeAnchor1(anchor interface{}) (interface{}, error) {
+	return TimeAnchor(strings.ToLower(toIfaceStr(anchor))), nil
+}
+
+func (p *parser) callonTimeAnchor1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onTimeAnchor1(stack["anchor"])
+}
+
+func (c *current) onRangeAnchor1(anchor interface{}) (interface{}, error) {
+	return rangeAnchor{anchor: anchor.(TimeAnchor), text: string(c.text)}, nil
+}
+
+func (p *parser) callonRangeAnchor1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onRangeAnchor1(stack["anchor"])
+}
+
 func (c *current) onWildCard1() (interface{}, error) {
 	return "*", nil
 }
@@ -3,7 +3,9 @@ package lucenequery
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/andreyvit/diff"
 	"github.com/google/go-cmp/cmp"
@@ -30,10 +32,10 @@ func typeOf(v interface{}) reflect.Type{
 	return reflect.TypeOf(v)
 }
 
-func executeTestCases(t *testing.T, cases []TestCase) {
+func executeTestCases(t *testing.T, cases []TestCase, opts ...Option) {
 	for i, test := range cases {
 		for j, q := range test.queries {
-			got, err := Parse("TestScalarValues", []byte(q))
+			got, err := Parse("TestScalarValues", []byte(q), opts...)
 			if err != nil {
 				t.Fatalf("Expected to parse %s without error, got: %v", q, err)
 			}
@@ -43,6 +45,7 @@ func executeTestCases(t *testing.T, cases []TestCase) {
 				t.Fatalf("[%v,%v]Expected lucenequery {%s}`%s` to equal {%s}\n\t%s, \n\t\t got \n\t%s\n\t\t diff \n\t%s",
 					i, j, expectedType, q, gotType, expectedValue, gotValue, diff.CharacterDiff(expectedValue, gotValue))
 			}
+			assertRoundTrip(t, q, got, opts...)
 		}
 	}
 }
@@ -73,6 +76,30 @@ func TestTermQueries(t *testing.T) {
 			queries:  []string{`available: true`},
 			expected: &TermQuery{Term: "available", Value: true, Op: ""},
 		},
+		{
+			queries:  []string{`code: TRUE`},
+			expected: &TermQuery{Term: "code", Value: "TRUE", Op: ""},
+		},
+		{
+			queries:  []string{`code: eq False`},
+			expected: &TermQuery{Term: "code", Value: "False", Op: "eq"},
+		},
+		{
+			queries:  []string{`available: eq true`},
+			expected: &TermQuery{Term: "available", Value: true, Op: "eq"},
+		},
+		{
+			queries:  []string{`available: neq false`, `available: != false`},
+			expected: &TermQuery{Term: "available", Value: false, Op: "neq"},
+		},
+		{
+			queries:  []string{`available: trueish`},
+			expected: &TermQuery{Term: "available", Value: "trueish", Op: ""},
+		},
+		{
+			queries:  []string{`available: [true,FALSE]`},
+			expected: &TermQuery{Term: "available", Value: []interface{}{true, "FALSE"}, Op: "in"},
+		},
 		{
 			queries:  []string{`age: 23`},
 			expected: &TermQuery{Term: "age", Value: 23, Op: ""},
@@ -89,6 +116,26 @@ func TestTermQueries(t *testing.T) {
 			queries:  []string{`metric: -123.456`},
 			expected: &TermQuery{Term: "metric", Value: -123.456, Op: ""},
 		},
+		{
+			queries:  []string{`metric: 1.5e9`, `metric: 1.5E+9`, `metric: 15e8`},
+			expected: &TermQuery{Term: "metric", Value: 1.5e9, Op: ""},
+		},
+		{
+			queries:  []string{`metric: 2E-3`, `metric: 2e-3`},
+			expected: &TermQuery{Term: "metric", Value: 0.002, Op: ""},
+		},
+		{
+			queries:  []string{`metric: -4.2e-2`},
+			expected: &TermQuery{Term: "metric", Value: -0.042, Op: ""},
+		},
+		{
+			queries:  []string{`commit: 12e4567`},
+			expected: &TermQuery{Term: "commit", Value: "12e4567", Op: ""},
+		},
+		{
+			queries:  []string{`metric: -1e999`},
+			expected: &TermQuery{Term: "metric", Value: "-1e999", Op: ""},
+		},
 		{
 			queries:  []string{`quote: "a walk in the \"park\""`},
 			expected: &TermQuery{Term: "quote", Value: `a walk in the "park"`, Op: ""},
@@ -100,6 +147,414 @@ func TestTermQueries(t *testing.T) {
 	})
 }
 
+func TestByteSizeQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`size: 10kb`, `size: 10KB`},
+			expected: &TermQuery{Term: "size", Value: 10240, Op: ""},
+		},
+		{
+			queries:  []string{`size: 10mb`, `size: 10Mb`},
+			expected: &TermQuery{Term: "size", Value: 10485760, Op: ""},
+		},
+		{
+			queries:  []string{`size: 2gb`},
+			expected: &TermQuery{Term: "size", Value: 2147483648, Op: ""},
+		},
+		{
+			queries:  []string{`size: 1.5kb`},
+			expected: &TermQuery{Term: "size", Value: 1536, Op: ""},
+		},
+		{
+			queries:  []string{`size: > 10mb`, `size: gt 10mb`, `size: {10mb TO *}`},
+			expected: RangeQuery{Min: 10485760, Max: "*", Term: "size", Inclusive: false},
+		},
+		{
+			queries:  []string{`size: [1kb TO 1mb]`},
+			expected: RangeQuery{Min: 1024, Max: 1048576, Term: "size", Inclusive: true},
+		},
+		{
+			queries:  []string{`size: [1kb, 2kb]`},
+			expected: &TermQuery{Term: "size", Value: []interface{}{1024, 2048}, Op: "in"},
+		},
+		{
+			queries:  []string{`size: 10mbps`},
+			expected: BooleanExpression{
+				Op: "IMPLICIT",
+				Args: []interface{}{
+					TermQuery{Term: "size", Value: 10},
+					TermQuery{Value: "mbps"},
+				},
+			},
+		},
+	})
+
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`size: 10kb`},
+			expected: &TermQuery{Term: "size", Value: 10000, Op: ""},
+		},
+		{
+			queries:  []string{`size: 10mb`},
+			expected: &TermQuery{Term: "size", Value: 10000000, Op: ""},
+		},
+		{
+			queries:  []string{`size: 2gb`},
+			expected: &TermQuery{Term: "size", Value: 2000000000, Op: ""},
+		},
+	}, WithByteSizeBase(1000))
+}
+
+func TestDecimalCommaQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`price: 23,5`, `price: 23.5`, `price: float:"23,5"`},
+			expected: TermQuery{Term: "price", Value: 23.5},
+		},
+		{
+			queries:  []string{`price: >= -1,25`},
+			expected: RangeQuery{Term: "price", Min: -1.25, Max: "*", Inclusive: true},
+		},
+		{
+			queries:  []string{`price: [1,5 TO 2,75]`, `price: 1,5..2,75`},
+			expected: RangeQuery{Term: "price", Min: 1.5, Max: 2.75, Inclusive: true},
+		},
+		{
+			queries:  []string{`price: [1,5]`, `price: [1, 5]`},
+			expected: TermQuery{Term: "price", Op: "in", Value: []interface{}{1, 5}},
+		},
+		{
+			queries:  []string{`price: "23,5"`},
+			expected: TermQuery{Term: "price", Value: "23,5"},
+		},
+	}, WithDecimalComma())
+
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`price: [1,5]`},
+			expected: TermQuery{Term: "price", Op: "in", Value: []interface{}{1, 5}},
+		},
+	})
+}
+
+func TestWordOperatorQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`age: greater_than 18`, `age: GREATER_THAN 18`, `age: > 18`},
+			expected: RangeQuery{Min: 18, Max: "*", Term: "age", Inclusive: false},
+		},
+		{
+			queries:  []string{`age: less_than_or_equal 18`},
+			expected: RangeQuery{Min: "*", Max: 18, Term: "age", Inclusive: true},
+		},
+		{
+			queries:  []string{`name: not_equal "peter"`, `name: != "peter"`},
+			expected: &TermQuery{Term: "name", Value: "peter", Op: "neq"},
+		},
+		{
+			queries:  []string{`name: equals peter`},
+			expected: &TermQuery{Term: "name", Value: "peter", Op: "eq"},
+		},
+		{
+			queries:  []string{`name: greater_than`},
+			expected: &TermQuery{Term: "name", Value: "greater_than", Op: ""},
+		},
+		{
+			queries:  []string{`name: equality`},
+			expected: &TermQuery{Term: "name", Value: "equality", Op: ""},
+		},
+	}, WithWordOperators(nil))
+
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`age: over 18`},
+			expected: RangeQuery{Min: 18, Max: "*", Term: "age", Inclusive: false},
+		},
+		{
+			queries: []string{`age: greater_than 18`},
+			expected: BooleanExpression{
+				Op: "IMPLICIT",
+				Args: []interface{}{
+					TermQuery{Term: "age", Value: "greater_than"},
+					TermQuery{Value: 18},
+				},
+			},
+		},
+	}, WithWordOperators(map[string]string{"over": "gt"}))
+
+	executeTestCases(t, []TestCase{
+		{
+			queries: []string{`age: greater_than 18`},
+			expected: BooleanExpression{
+				Op: "IMPLICIT",
+				Args: []interface{}{
+					TermQuery{Term: "age", Value: "greater_than"},
+					TermQuery{Value: 18},
+				},
+			},
+		},
+	})
+}
+
+func TestArrayQuantifierQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`tags: all ["a", "b"]`, `tags:ALL["a","b"]`},
+			expected: TermQuery{Term: "tags", Op: "all", Value: []interface{}{"a", "b"}},
+		},
+		{
+			queries:  []string{`tags: any ["a", "b"]`, `tags: Any [ "a" , "b" ]`},
+			expected: TermQuery{Term: "tags", Op: "any", Value: []interface{}{"a", "b"}},
+		},
+		{
+			queries:  []string{`tags: ["a", "b"]`},
+			expected: TermQuery{Term: "tags", Op: "in", Value: []interface{}{"a", "b"}},
+		},
+		{
+			queries:  []string{`name: anything`},
+			expected: TermQuery{Term: "name", Value: "anything"},
+		},
+	})
+}
+
+func TestNullCoalesceQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`status ?? "open"`, `status ?? open`, `status: ?? open`, `status:??open`},
+			expected: TermQuery{Term: "status", Op: "??", Value: "open"},
+		},
+		{
+			queries:  []string{`"order status" ?? 2`},
+			expected: TermQuery{Term: "order status", Op: "??", Value: 2},
+		},
+		{
+			queries:  []string{`-status ?? open`, `-status: ?? open`, `-"status" ?? open`},
+			expected: TermQuery{Term: "status", Prefix: "-", Op: "??", Value: "open"},
+		},
+		{
+			queries:  []string{`+status ?? open`},
+			expected: TermQuery{Term: "status", Prefix: "+", Op: "??", Value: "open"},
+		},
+		{
+			queries: []string{`status ?? open AND age: 5`},
+			expected: BooleanExpression{Op: "AND", Args: []interface{}{
+				TermQuery{Term: "status", Op: "??", Value: "open"},
+				TermQuery{Term: "age", Value: 5},
+			}},
+		},
+	})
+}
+
+func TestTimeAnchorQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`created: < today`, `created:<TODAY`},
+			expected: RangeQuery{Term: "created", Min: "*", Max: TimeAnchor("today")},
+		},
+		{
+			queries:  []string{`created: >= yesterday`},
+			expected: RangeQuery{Term: "created", Min: TimeAnchor("yesterday"), Max: "*", Inclusive: true},
+		},
+		{
+			queries:  []string{`created: [yesterday TO now]`},
+			expected: RangeQuery{Term: "created", Min: TimeAnchor("yesterday"), Max: TimeAnchor("now"), Inclusive: true},
+		},
+		{
+			queries:  []string{`created: ["2021-02-28" TO now]`},
+			expected: RangeQuery{Term: "created", Min: "2021-02-28", Max: TimeAnchor("now"), Inclusive: true},
+		},
+		{
+			queries:  []string{`name: [now TO zebra]`},
+			expected: RangeQuery{Term: "name", Min: "now", Max: "zebra", Inclusive: true},
+		},
+		{
+			queries:  []string{`name: {Today TO 5}`},
+			expected: RangeQuery{Term: "name", Min: "Today", Max: 5},
+		},
+		{
+			queries:  []string{`title: today`},
+			expected: TermQuery{Term: "title", Value: "today"},
+		},
+		{
+			queries:  []string{`title: != nowhere`},
+			expected: TermQuery{Term: "title", Op: "neq", Value: "nowhere"},
+		},
+	})
+}
+
+func TestTimeAnchor(t *testing.T) {
+	now := time.Date(2021, 3, 1, 15, 4, 5, 0, time.UTC)
+	cases := map[TimeAnchor]time.Time{
+		"today":     time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		"yesterday": time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC),
+		"now":       now,
+	}
+	for anchor, expected := range cases {
+		if got := anchor.Time(now); !got.Equal(expected) {
+			t.Errorf("Expected %s to be %v, got: %v", anchor, expected, got)
+		}
+	}
+}
+
+func TestAllFieldQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`_all: foo`, `"_all":foo`, `foo`},
+			expected: TermQuery{Value: "foo"},
+		},
+		{
+			queries:  []string{`_all: (foo OR bar)`, `(foo OR bar)`},
+			expected: BooleanExpression{Op: "OR", Args: []interface{}{TermQuery{Value: "foo"}, TermQuery{Value: "bar"}}},
+		},
+		{
+			queries:  []string{`-_all: foo`},
+			expected: TermQuery{Prefix: "-", Value: "foo"},
+		},
+		{
+			queries:  []string{`_all_fields: foo`},
+			expected: TermQuery{Term: "_all_fields", Value: "foo"},
+		},
+	})
+}
+
+func TestDefaultFieldQueries(t *testing.T) {
+	cases := map[string]interface{}{
+		`>= 5`: RangeQuery{Term: "age", Min: 5, Max: "*", Inclusive: true},
+		`>= 5 <= 20`: BooleanExpression{Op: "IMPLICIT", Args: []interface{}{
+			RangeQuery{Term: "age", Min: 5, Max: "*", Inclusive: true},
+			RangeQuery{Term: "age", Min: "*", Max: 20, Inclusive: true},
+		}},
+		`[1 TO 5] OR name: peter`: BooleanExpression{Op: "OR", Args: []interface{}{
+			RangeQuery{Term: "age", Min: 1, Max: 5, Inclusive: true},
+			TermQuery{Term: "name", Value: "peter"},
+		}},
+		`(5 OR 6)`: BooleanExpression{Op: "OR", Args: []interface{}{
+			TermQuery{Term: "age", Value: 5},
+			TermQuery{Term: "age", Value: 6},
+		}},
+	}
+	for query, expected := range cases {
+		got, err := Parse("TestDefaultFieldQueries", []byte(query), WithDefaultField("age"), WithRequireField())
+		if err != nil {
+			t.Errorf("Expected %s to parse without error, got: %v", query, err)
+			continue
+		}
+		if !reflect.DeepEqual(expected, got) {
+			t.Errorf("Expected %s to parse as %#v, got: %#v", query, expected, got)
+		}
+	}
+}
+
+func TestQuotedOperatorQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries: []string{`a "OR" b`},
+			expected: BooleanExpression{Op: "IMPLICIT", Args: []interface{}{
+				TermQuery{Value: "a"},
+				BooleanExpression{Op: "IMPLICIT", Args: []interface{}{TermQuery{Value: "OR"}, TermQuery{Value: "b"}}},
+			}},
+		},
+		{
+			queries: []string{`a "AND" b`},
+			expected: BooleanExpression{Op: "IMPLICIT", Args: []interface{}{
+				TermQuery{Value: "a"},
+				BooleanExpression{Op: "IMPLICIT", Args: []interface{}{TermQuery{Value: "AND"}, TermQuery{Value: "b"}}},
+			}},
+		},
+		{
+			queries:  []string{`"NOT" a`},
+			expected: BooleanExpression{Op: "IMPLICIT", Args: []interface{}{TermQuery{Value: "NOT"}, TermQuery{Value: "a"}}},
+		},
+		{
+			queries:  []string{`"OR"`},
+			expected: TermQuery{Value: "OR"},
+		},
+		{
+			queries:  []string{`title: "AND" OR "||"`},
+			expected: BooleanExpression{Op: "OR", Args: []interface{}{TermQuery{Term: "title", Value: "AND"}, TermQuery{Value: "||"}}},
+		},
+	})
+}
+
+func TestColonValueQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`url: https://x/y`, `url:https://x/y`, `url: "https://x/y"`},
+			expected: TermQuery{Term: "url", Value: "https://x/y"},
+		},
+		{
+			queries:  []string{`ref: a:b:c`, `ref:a:b:c`},
+			expected: TermQuery{Term: "ref", Value: "a:b:c"},
+		},
+		{
+			queries:  []string{`-url: https://x/y?q=1`},
+			expected: TermQuery{Term: "url", Prefix: "-", Value: "https://x/y?q=1"},
+		},
+		{
+			queries: []string{`url: https://x/y AND name: peter`, `(url: https://x/y) AND name: peter`},
+			expected: BooleanExpression{Op: "AND", Args: []interface{}{
+				TermQuery{Term: "url", Value: "https://x/y"},
+				TermQuery{Term: "name", Value: "peter"},
+			}},
+		},
+		{
+			queries:  []string{`zip:string:02134`},
+			expected: TermQuery{Term: "zip", Value: "02134"},
+		},
+		{
+			queries:  []string{`a: b: c`, `a:b: c`, `a: b:`},
+			expected: TermQuery{Term: "a", Value: "b"},
+		},
+		{
+			queries:  []string{`a: b:c`},
+			expected: TermQuery{Term: "a", Value: "b:c"},
+		},
+	})
+}
+
+func TestGroupedComparisonQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`price:(> 10 < 100)`, `price:(>10 <100)`, `price:(< 100 > 10)`, `price:(> 10 AND < 100)`},
+			expected: RangeQuery{Term: "price", Min: 10, Max: 100, Inclusive: false},
+		},
+		{
+			queries:  []string{`price:(>= 10 <= 100)`, `price:(<= 100 AND >= 10)`},
+			expected: RangeQuery{Term: "price", Min: 10, Max: 100, Inclusive: true},
+		},
+		{
+			queries: []string{`price:(>= 10 < 100)`},
+			expected: BooleanExpression{
+				Op: "AND",
+				Args: []interface{}{
+					RangeQuery{Term: "price", Min: 10, Max: "*", Inclusive: true},
+					RangeQuery{Term: "price", Min: "*", Max: 100, Inclusive: false},
+				},
+			},
+		},
+		{
+			queries: []string{`price:(> 10 OR < 5)`},
+			expected: BooleanExpression{
+				Op: "OR",
+				Args: []interface{}{
+					RangeQuery{Term: "price", Min: 10, Max: "*", Inclusive: false},
+					RangeQuery{Term: "price", Min: "*", Max: 5, Inclusive: false},
+				},
+			},
+		},
+		{
+			queries: []string{`price:(> 10 > 100)`},
+			expected: BooleanExpression{
+				Op: "IMPLICIT",
+				Args: []interface{}{
+					RangeQuery{Term: "price", Min: 10, Max: "*", Inclusive: false},
+					RangeQuery{Term: "price", Min: 100, Max: "*", Inclusive: false},
+				},
+			},
+		},
+	})
+}
+
 func TestRangeQueries(t *testing.T) {
 	executeTestCases(t, []TestCase{
 		{
@@ -142,6 +597,18 @@ func TestRangeQueries(t *testing.T) {
 			queries:  []string{`metric: {* TO 3.14}`, `metric: < 3.14`, `metric: lt 3.14`},
 			expected: RangeQuery{Min: "*", Max: 3.14, Term: "metric", Inclusive: false},
 		},
+		{
+			queries:  []string{`metric: > 1.5e9`, `metric: {1.5e9 TO *}`},
+			expected: RangeQuery{Min: 1.5e9, Max: "*", Term: "metric", Inclusive: false},
+		},
+		{
+			queries:  []string{`metric: [-1e-3 TO 2E3]`},
+			expected: RangeQuery{Min: -0.001, Max: 2000.0, Term: "metric", Inclusive: true},
+		},
+		{
+			queries:  []string{`metric: [1 TO 1e999]`},
+			expected: RangeQuery{Min: 1, Max: "1e999", Term: "metric", Inclusive: true},
+		},
 		{
 			queries:  []string{`metric: {* TO *}`},
 			expected: RangeQuery{Min: "*", Max: "*", Term: "metric", Inclusive: false},
@@ -149,6 +616,73 @@ func TestRangeQueries(t *testing.T) {
 	})
 }
 
+func TestDotRangeQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`age:18..25`, `age: 18..25`, `age: [18 TO 25]`},
+			expected: RangeQuery{Term: "age", Min: 18, Max: 25, Inclusive: true},
+		},
+		{
+			queries:  []string{`age:>18..<25`, `age: {18 TO 25}`},
+			expected: RangeQuery{Term: "age", Min: 18, Max: 25, Inclusive: false},
+		},
+		{
+			queries:  []string{`age:18..`, `age: >= 18`},
+			expected: RangeQuery{Term: "age", Min: 18, Max: "*", Inclusive: true},
+		},
+		{
+			queries:  []string{`age:>18..`, `age: > 18`},
+			expected: RangeQuery{Term: "age", Min: 18, Max: "*", Inclusive: false},
+		},
+		{
+			queries:  []string{`age:..25`, `age: <= 25`},
+			expected: RangeQuery{Term: "age", Min: "*", Max: 25, Inclusive: true},
+		},
+		{
+			queries:  []string{`age:..<25`, `age: < 25`},
+			expected: RangeQuery{Term: "age", Min: "*", Max: 25, Inclusive: false},
+		},
+		{
+			queries:  []string{`price:1.5..-2.5e3`},
+			expected: RangeQuery{Term: "price", Min: 1.5, Max: -2500.0, Inclusive: true},
+		},
+		{
+			queries:  []string{`stats.score: [0 TO 100]`, `stats.score:0..100`},
+			expected: RangeQuery{Term: "stats.score", Min: 0, Max: 100, Inclusive: true},
+		},
+		{
+			queries:  []string{`created:"2021-01-01".."2021-02-01"`},
+			expected: RangeQuery{Term: "created", Min: "2021-01-01", Max: "2021-02-01", Inclusive: true},
+		},
+		{
+			queries: []string{`age:18..<25`},
+			expected: BooleanExpression{
+				Op: "AND",
+				Args: []interface{}{
+					RangeQuery{Term: "age", Min: 18, Max: "*", Inclusive: true},
+					RangeQuery{Term: "age", Min: "*", Max: 25, Inclusive: false},
+				},
+			},
+		},
+		{
+			queries: []string{`age:>18..25 AND size:1kb..`},
+			expected: BooleanExpression{
+				Op: "AND",
+				Args: []interface{}{
+					BooleanExpression{
+						Op: "AND",
+						Args: []interface{}{
+							RangeQuery{Term: "age", Min: 18, Max: "*", Inclusive: false},
+							RangeQuery{Term: "age", Min: "*", Max: 25, Inclusive: true},
+						},
+					},
+					RangeQuery{Term: "size", Min: 1024, Max: "*", Inclusive: true},
+				},
+			},
+		},
+	})
+}
+
 func TestBooleanQueries(t *testing.T) {
 	executeTestCases(t, []TestCase{
 		{
@@ -220,8 +754,8 @@ func TestBooleanQueries(t *testing.T) {
 			},
 		},
 		{
-			queries:  []string{`NOT "Apache Lucene"`},
-			expected: TermQuery{Op: "", Value: "Apache Lucene"},
+			queries:  []string{`NOT "Apache Lucene"`, `-"Apache Lucene"`},
+			expected: TermQuery{Op: "", Prefix: "-", Value: "Apache Lucene"},
 		},
 		{
 			queries: []string{`title:(+return +"pink panther")`},
@@ -252,6 +786,81 @@ func TestBooleanQueries(t *testing.T) {
 	})
 }
 
+func TestNegatedQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`NOT status:closed`, `-status:closed`, `status: -closed`, `not status:closed`, `NOT NOT -status:closed`},
+			expected: TermQuery{Term: "status", Prefix: "-", Value: "closed"},
+		},
+		{
+			queries:  []string{`+status:open`, `status: +open`},
+			expected: TermQuery{Term: "status", Prefix: "+", Value: "open"},
+		},
+		{
+			queries: []string{
+				`name: peter AND NOT status:closed`,
+				`name: peter AND -status:closed`,
+				`name: peter AND status: -closed`,
+			},
+			expected: BooleanExpression{
+				Op: "AND",
+				Args: []interface{}{
+					TermQuery{Term: "name", Value: "peter"},
+					TermQuery{Term: "status", Prefix: "-", Value: "closed"},
+				},
+			},
+		},
+		{
+			queries: []string{`NOT status:closed AND name: peter`},
+			expected: BooleanExpression{
+				Op: "AND",
+				Args: []interface{}{
+					TermQuery{Term: "status", Prefix: "-", Value: "closed"},
+					TermQuery{Term: "name", Value: "peter"},
+				},
+			},
+		},
+		{
+			queries: []string{`name: peter NOT status:closed`},
+			expected: BooleanExpression{
+				Op: "NOT",
+				Args: []interface{}{
+					TermQuery{Term: "name", Value: "peter"},
+					TermQuery{Term: "status", Value: "closed"},
+				},
+			},
+		},
+		{
+			queries: []string{`NOT (status:closed OR status:draft)`, `-status:(closed OR draft)`},
+			expected: BooleanExpression{
+				Op: "NOT",
+				Args: []interface{}{
+					BooleanExpression{
+						Op: "OR",
+						Args: []interface{}{
+							TermQuery{Term: "status", Value: "closed"},
+							TermQuery{Term: "status", Value: "draft"},
+						},
+					},
+				},
+			},
+		},
+		{
+			queries: []string{`NOT age:[1 TO 5]`, `-age:[1 TO 5]`},
+			expected: BooleanExpression{
+				Op: "NOT",
+				Args: []interface{}{
+					RangeQuery{Term: "age", Min: 1, Max: 5, Inclusive: true},
+				},
+			},
+		},
+		{
+			queries:  []string{`age: -5`},
+			expected: TermQuery{Term: "age", Value: -5},
+		},
+	})
+}
+
 func TestWildCardQueries(t *testing.T) {
 	executeTestCases(t, []TestCase{
 		{
@@ -293,4 +902,308 @@ func TestWildCardQueries(t *testing.T) {
 			},
 		},
 	})
-}
\ No newline at end of file
+}
+
+func TestCommentQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries: []string{
+				`name: foo // internal note`,
+				`name: /* inline */ foo`,
+				`/* leading */ name: foo`,
+				"// leading\nname: foo /* trailing */",
+				"\ufeff// leading\nname: foo",
+				"name: foo\n// note",
+			},
+			expected: &TermQuery{Term: "name", Value: "foo"},
+		},
+		{
+			queries:  []string{`name: "a // b /* c */"`},
+			expected: &TermQuery{Term: "name", Value: "a // b /* c */"},
+		},
+		{
+			queries: []string{
+				`name: foo /* both */ AND /* are required */ age: 5`,
+				"name: foo // note\nAND age: 5 // other note",
+			},
+			expected: BooleanExpression{
+				Op: "AND",
+				Args: []interface{}{
+					TermQuery{Term: "name", Value: "foo"},
+					TermQuery{Term: "age", Value: 5},
+				},
+			},
+		},
+		{
+			queries:  []string{`http://example.com`, `http://example.com // note`},
+			expected: &TermQuery{Term: "http", Value: "//example.com"},
+		},
+		{
+			queries:  []string{`url: https://example.com/path`},
+			expected: &TermQuery{Term: "url", Value: "https://example.com/path"},
+		},
+		{
+			queries: []string{`path: //server/share status: open`, `path: /* unc */ //server/share status: open`},
+			expected: &BooleanExpression{Op: "IMPLICIT", Args: []interface{}{
+				TermQuery{Term: "path", Value: "//server/share"},
+				TermQuery{Term: "status", Value: "open"},
+			}},
+		},
+		{
+			queries:  []string{`path:(//server/share)`},
+			expected: &TermQuery{Term: "path", Value: "//server/share"},
+		},
+	})
+}
+
+func TestWhitespaceQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries: []string{
+				"\ufeffname: foo",
+				"\ufeff name:\u00a0foo",
+				"name:\tfoo\n",
+			},
+			expected: &TermQuery{Term: "name", Value: "foo"},
+		},
+		{
+			queries: []string{
+				"name: foo\u00a0AND\u00a0age: 5",
+				"\ufeffname: foo\tAND\r\nage: 5",
+			},
+			expected: BooleanExpression{
+				Op: "AND",
+				Args: []interface{}{
+					TermQuery{Term: "name", Value: "foo"},
+					TermQuery{Term: "age", Value: 5},
+				},
+			},
+		},
+	})
+}
+
+func TestRequireFieldQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`name: foo`},
+			expected: &TermQuery{Term: "name", Value: "foo"},
+		},
+		{
+			queries: []string{`name: foo AND age: [1 TO 5]`},
+			expected: BooleanExpression{
+				Op: "AND",
+				Args: []interface{}{
+					TermQuery{Term: "name", Value: "foo"},
+					RangeQuery{Term: "age", Min: 1, Max: 5, Inclusive: true},
+				},
+			},
+		},
+		{
+			queries: []string{`name:(foo OR bar)`},
+			expected: BooleanExpression{
+				Op: "OR",
+				Args: []interface{}{
+					TermQuery{Term: "name", Value: "foo"},
+					TermQuery{Term: "name", Value: "bar"},
+				},
+			},
+		},
+	}, WithRequireField())
+
+	cases := map[string]string{
+		`foo`:                   "term `foo` has no field",
+		`name: foo AND bar*`:    "term `bar*` has no field",
+		`name: foo OR [1 TO 5]`: "range `[1 TO 5]` has no field",
+	}
+	for q, expected := range cases {
+		_, err := Parse("TestRequireField", []byte(q), WithRequireField())
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("Expected lucenequery `%s` to fail with `%s`, got: %v", q, expected, err)
+		}
+		if _, err := Parse("TestRequireField", []byte(q)); err != nil {
+			t.Fatalf("Expected to parse %s without error, got: %v", q, err)
+		}
+	}
+}
+
+func TestMaxValueLengthQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`name: abcde`},
+			expected: &TermQuery{Term: "name", Value: "abcde"},
+		},
+		{
+			queries:  []string{`name: ab*de`},
+			expected: &TermQuery{Term: "name", Value: WildCardQuery{Prefix: "ab", Suffix: "de"}},
+		},
+	}, WithMaxValueLength(5))
+
+	cases := map[string]string{
+		`name: abcdef`:                  "value of `name` has 6 characters, limit is 5",
+		`name: foo AND title: ab*cdefg`: "value of `title` has 7 characters, limit is 5",
+		`name: "ééééééé"`:               "value of `name` has 7 characters, limit is 5",
+		`name: [a TO abcdefgh]`:         "value of `name` has 8 characters, limit is 5",
+		`name: ["foo", "abcdefgh"]`:     "value of `name` has 8 characters, limit is 5",
+	}
+	for q, expected := range cases {
+		_, err := Parse("TestMaxValueLength", []byte(q), WithMaxValueLength(5))
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("Expected lucenequery `%s` to fail with `%s`, got: %v", q, expected, err)
+		}
+		if _, err := Parse("TestMaxValueLength", []byte(q)); err != nil {
+			t.Fatalf("Expected to parse %s without error, got: %v", q, err)
+		}
+	}
+}
+
+func TestContainsQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`period: contains "2020-06-01"`, `period:contains"2020-06-01"`},
+			expected: &TermQuery{Term: "period", Value: "2020-06-01", Op: "contains"},
+		},
+		{
+			queries:  []string{`-period: contains 5`},
+			expected: &TermQuery{Term: "period", Value: 5, Op: "contains", Prefix: "-"},
+		},
+		{
+			queries:  []string{`body: contains`, `body:(contains)`, `body: "contains"`},
+			expected: &TermQuery{Term: "body", Value: "contains"},
+		},
+		{
+			queries: []string{`name: contains status: open`},
+			expected: &BooleanExpression{Op: "IMPLICIT", Args: []interface{}{
+				TermQuery{Term: "name", Value: "contains"},
+				TermQuery{Term: "status", Value: "open"},
+			}},
+		},
+		{
+			queries: []string{`name: contains AND status: open`},
+			expected: &BooleanExpression{Op: "AND", Args: []interface{}{
+				TermQuery{Term: "name", Value: "contains"},
+				TermQuery{Term: "status", Value: "open"},
+			}},
+		},
+		{
+			queries:  []string{`name: contains android`},
+			expected: &TermQuery{Term: "name", Value: "android", Op: "contains"},
+		},
+	})
+}
+
+func TestMaxFieldsQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries: []string{`name: foo AND (name: bar OR age: > 5)`},
+			expected: &BooleanExpression{Op: "AND", Args: []interface{}{
+				TermQuery{Term: "name", Value: "foo"},
+				BooleanExpression{Op: "OR", Args: []interface{}{
+					TermQuery{Term: "name", Value: "bar"},
+					RangeQuery{Term: "age", Min: 5, Max: "*"},
+				}},
+			}},
+		},
+		{
+			queries: []string{`name: foo bar baz`},
+			expected: &BooleanExpression{Op: "IMPLICIT", Args: []interface{}{
+				TermQuery{Term: "name", Value: "foo"},
+				BooleanExpression{Op: "IMPLICIT", Args: []interface{}{
+					TermQuery{Value: "bar"},
+					TermQuery{Value: "baz"},
+				}},
+			}},
+		},
+	}, WithMaxFields(2))
+
+	cases := map[string]string{
+		`name: foo AND age: 5 AND city: x`: "field `city` exceeds the limit of 2 fields",
+		`a: 1 OR (b: [1 TO 2] AND -c: *x)`: "field `c` exceeds the limit of 2 fields",
+		`a: 1 b: 2 a: 3 NOT d: ["x", "y"]`: "field `d` exceeds the limit of 2 fields",
+	}
+	for q, expected := range cases {
+		_, err := Parse("TestMaxFields", []byte(q), WithMaxFields(2))
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("Expected lucenequery `%s` to fail with `%s`, got: %v", q, expected, err)
+		}
+		if _, err := Parse("TestMaxFields", []byte(q)); err != nil {
+			t.Fatalf("Expected to parse %s without error, got: %v", q, err)
+		}
+	}
+}
+
+func TestCastQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`id::int: "5"`, `id::INT:"5"`},
+			expected: &TermQuery{Term: "id", Value: "5", Cast: "int"},
+		},
+		{
+			queries:  []string{`-"first id"::bigint: != 5`},
+			expected: &TermQuery{Term: "first id", Value: 5, Op: "neq", Prefix: "-", Cast: "bigint"},
+		},
+		{
+			queries:  []string{`created::date: >= "2020-01-01"`},
+			expected: &RangeQuery{Term: "created", Min: "2020-01-01", Max: "*", Inclusive: true, Cast: "date"},
+		},
+		{
+			queries: []string{`id::int:(1 OR [5 TO 10])`},
+			expected: &BooleanExpression{Op: "OR", Args: []interface{}{
+				TermQuery{Term: "id", Value: 1, Cast: "int"},
+				RangeQuery{Term: "id", Min: 5, Max: 10, Inclusive: true, Cast: "int"},
+			}},
+		},
+	})
+
+	cases := map[string]string{
+		`id::int4: "5"`:                 "`int4` is not a supported type for field `id`",
+		`name: x AND id::pg_sleep: "5"`: "`pg_sleep` is not a supported type for field `id`",
+	}
+	for q, expected := range cases {
+		_, err := Parse("TestCast", []byte(q))
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("Expected lucenequery `%s` to fail with `%s`, got: %v", q, expected, err)
+		}
+	}
+}
+
+func TestTypeAnnotationQueries(t *testing.T) {
+	executeTestCases(t, []TestCase{
+		{
+			queries:  []string{`zip:string:02134`, `zip: string:"02134"`},
+			expected: &TermQuery{Term: "zip", Value: "02134"},
+		},
+		{
+			queries:  []string{`count:int:5`, `count:int:"5"`, `count:5`},
+			expected: &TermQuery{Term: "count", Value: 5},
+		},
+		{
+			queries:  []string{`price:float:5`, `price:float:5.0`},
+			expected: &TermQuery{Term: "price", Value: 5.0},
+		},
+		{
+			queries:  []string{`active:bool:true`, `active:bool:1`, `active:bool:"T"`},
+			expected: &TermQuery{Term: "active", Value: true},
+		},
+		{
+			queries:  []string{`count:int: > 5`, `count:int:>5`},
+			expected: RangeQuery{Term: "count", Min: 5, Max: "*"},
+		},
+		{
+			queries: []string{`zip:string:02134 AND count:int:5`},
+			expected: BooleanExpression{
+				Op: "AND",
+				Args: []interface{}{
+					TermQuery{Term: "zip", Value: "02134"},
+					TermQuery{Term: "count", Value: 5},
+				},
+			},
+		},
+	})
+
+	for _, q := range []string{`count:int:five`, `price:float:abc`, `active:bool:maybe`} {
+		_, err := Parse("TestTypeAnnotationQueries", []byte(q))
+		if err == nil {
+			t.Fatalf("Expected %s to fail parsing", q)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package sql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stevejuma/pkg/lucenequery"
+)
+
+// describer is a Visitor rendering a human readable description of the query instead of SQL
+type describer struct {
+	*Generator
+}
+
+// descriptions of the term operators
+var describedOperators = map[string]string{
+	"":         "is",
+	"eq":       "is",
+	"neq":      "is not",
+	"in":       "is one of",
+	"all":      "has all of",
+	"any":      "has any of",
+	"~":        "matches",
+	"~*":       "matches",
+	"!~":       "does not match",
+	"!~*":      "does not match",
+	"??":       "is",
+	"contains": "contains",
+}
+
+// descriptions of the wildcard kinds and of their negation
+var wildcardDescriptions = map[string][2]string{
+	"prefix":   {"starts with %s", "does not start with %s"},
+	"suffix":   {"ends with %s", "does not end with %s"},
+	"between":  {"starts with %s and ends with %s", "does not start with %s and end with %s"},
+	"any":      {"contains %s", "does not contain %s"},
+	"wildcard": {"is set", "is not set"},
+}
+
+// Describe returns a human readable description of the filter for echoing searches back to users
+// e.g. `status: open AND age: [18 TO 25]` is described as `status is open AND age between 18 and 25`.
+// The filter is a query string or a parsed query
+func Describe(filter interface{}) (string, error) {
+	d := &describer{Generator: NewGenerator(&ToSQLOptions{})}
+	d.Visitor = d
+	if v, ok := filter.(string); ok {
+		var err error
+		if filter, err = d.parse(v); err != nil {
+			return "", err
+		}
+	}
+	if v, ok := filter.([]interface{}); ok {
+		filter = lucenequery.BooleanExpression{Op: "IMPLICIT", Args: v}
+	}
+	query, err := d.Visit(filter)
+	return unwrap(query.Query), err
+}
+
+// Visit describes the node with its values as written, time keywords are not resolved and the
+// values are not bound
+func (d *describer) Visit(filter interface{}) (Query, error) {
+	switch v := filter.(type) {
+	case lucenequery.BooleanExpression:
+		return d.VisitBoolean(v)
+	case lucenequery.TermQuery:
+		return d.VisitTerm(v)
+	case lucenequery.RangeQuery:
+		return d.VisitRange(v)
+	case map[string]interface{}:
+		if node := mapFilter(v); node != nil {
+			return d.Visit(node)
+		}
+	}
+	return d.Generator.Visit(filter)
+}
+
+// VisitBoolean describes the arguments joined by the operator, nested expressions are parenthesized.
+// Excluded arguments are described as such regardless of the search mode e.g. `a -b` is `a AND NOT b`
+func (d *describer) VisitBoolean(v lucenequery.BooleanExpression) (Query, error) {
+	query := Query{Query: "", Args: []interface{}{}, Columns: []string{}}
+	op := operatorMappings[v.Op]
+	switch op {
+	case "":
+		op = v.Op
+	case "NOT":
+		// a NOT b matches a without b
+		op = "AND NOT"
+	}
+	var sb strings.Builder
+	for i, arg := range v.Args {
+		q, err := d.Visit(arg)
+		if err != nil {
+			return query, err
+		}
+		if v.Op == "NOT" && len(v.Args) == 1 {
+			query.Query = "NOT " + q.Query
+			return query, nil
+		}
+		if i > 0 {
+			join := op
+			if v.Op == "IMPLICIT" && negated(arg) {
+				join = "AND"
+			}
+			sb.WriteString(" " + join + " ")
+		}
+		sb.WriteString(q.Query)
+	}
+	query.Query = fmt.Sprintf("(%s)", sb.String())
+	return query, nil
+}
+
+// VisitTerm describes the field compared with the value
+func (d *describer) VisitTerm(v lucenequery.TermQuery) (Query, error) {
+	query := Query{Query: "", Args: []interface{}{}, Columns: []string{}}
+	if t, ok := v.Value.(lucenequery.WildCardQuery); ok {
+		return d.Visitor.VisitWildcard(v.Term, v, t)
+	}
+	op, ok := describedOperators[v.Op]
+	if !ok {
+		op = v.Op
+	}
+	value := describeValue(v.Value)
+	switch {
+	case v.Value == nil:
+		value = "null"
+	case v.Op == "??":
+		value += " or null"
+	}
+	if v.Prefix == "-" {
+		op = negateDescription(op)
+	}
+	if v.Term == "" {
+		query.Query = value
+		if v.Prefix == "-" {
+			query.Query = "NOT " + value
+		}
+		return query, nil
+	}
+	query.Query = fmt.Sprintf("%s %s %s", v.Term, op, value)
+	return query, nil
+}
+
+// VisitRange describes the bounds of the range
+func (d *describer) VisitRange(v lucenequery.RangeQuery) (Query, error) {
+	query := Query{Query: "", Args: []interface{}{}, Columns: []string{}}
+	kind, err := v.Kind()
+	if err != nil {
+		return query, err
+	}
+	min, max := describeValue(v.Min), describeValue(v.Max)
+	switch kind {
+	case "between":
+		query.Query = fmt.Sprintf("%s between %s and %s", v.Term, min, max)
+		if !v.Inclusive {
+			query.Query += " exclusive"
+		}
+	case "gt":
+		query.Query = fmt.Sprintf("%s greater than %s", v.Term, min)
+	case "gte":
+		query.Query = fmt.Sprintf("%s at least %s", v.Term, min)
+	case "lt":
+		query.Query = fmt.Sprintf("%s less than %s", v.Term, max)
+	case "lte":
+		query.Query = fmt.Sprintf("%s at most %s", v.Term, max)
+	default:
+		query.Query = fmt.Sprintf("%s is any value", v.Term)
+	}
+	return query, nil
+}
+
+// VisitWildcard describes the wildcard pattern matched by the field
+func (d *describer) VisitWildcard(column string, v lucenequery.TermQuery, t lucenequery.WildCardQuery) (Query, error) {
+	query := Query{Query: "", Args: []interface{}{}, Columns: []string{}}
+	forms, negated := wildcardDescriptions[t.Kind()], 0
+	if v.Prefix == "-" {
+		negated = 1
+	}
+	expr := forms[negated]
+	switch t.Kind() {
+	case "prefix":
+		expr = fmt.Sprintf(expr, t.Prefix)
+	case "suffix":
+		expr = fmt.Sprintf(expr, t.Suffix)
+	case "between":
+		expr = fmt.Sprintf(expr, t.Prefix, t.Suffix)
+	case "any":
+		expr = fmt.Sprintf(expr, t.Term)
+	}
+	query.Query = strings.TrimSpace(column + " " + expr)
+	return query, nil
+}
+
+// describeValue returns the value as displayed in a description, lists are joined with commas
+func describeValue(value interface{}) string {
+	if values, ok := value.([]interface{}); ok {
+		var items []string
+		for _, v := range values {
+			items = append(items, describeValue(v))
+		}
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// negateDescription returns the negated description of the operator
+func negateDescription(op string) string {
+	switch {
+	case strings.HasPrefix(op, "is not"):
+		return "is" + strings.TrimPrefix(op, "is not")
+	case strings.HasPrefix(op, "is"):
+		return "is not" + strings.TrimPrefix(op, "is")
+	case strings.HasPrefix(op, "does not "):
+		return strings.TrimPrefix(op, "does not ")
+	case op == "contains":
+		return "does not contain"
+	}
+	return "not " + op
+}
@@ -0,0 +1,42 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	cases := map[string]string{
+		`status: open AND age: [18 TO 25]`:              `status is open AND age between 18 and 25`,
+		`name: gopher* OR body: *rust*`:                 `name starts with gopher OR body contains rust`,
+		`status: open AND (age: > 18 OR age: {1 TO 5})`: `status is open AND (age greater than 18 OR age between 1 and 5 exclusive)`,
+		`-status: closed`:                               `status is not closed`,
+		`status: ["open", "pending"]`:                   `status is one of open, pending`,
+		`deleted: null`:                                 `deleted is null`,
+		`name: *son AND -name: a*z`:                     `name ends with son AND name does not start with a and end with z`,
+		`title: go NOT (body: rust OR body: c)`:         `title is go AND NOT (body is rust OR body is c)`,
+		`a: 1 NOT b: 2`:                                 `a is 1 AND NOT b is 2`,
+		`a: 1 -b: 2`:                                    `a is 1 AND b is not 2`,
+		`a: 1 OR -b: 2`:                                 `a is 1 OR b is not 2`,
+		`created: >= yesterday`:                         `created at least yesterday`,
+		`created: [yesterday TO now]`:                   `created between yesterday and now`,
+		`a: 1 AND NOT b: 2`:                             `a is 1 AND b is not 2`,
+		`a: 1 AND -b: 2`:                                `a is 1 AND b is not 2`,
+		`"jakarta apache"`:                              `jakarta apache`,
+		`age: <= 5`:                                     `age at most 5`,
+		`-period: contains "2020-06-01"`:                `period does not contain 2020-06-01`,
+	}
+	for q, expected := range cases {
+		got, err := Describe(q)
+		assert.NoError(t, err, q)
+		assert.Equal(t, expected, got, q)
+	}
+
+	got, err := Describe(map[string]interface{}{"status": "open"})
+	assert.NoError(t, err)
+	assert.Equal(t, "status is open", got)
+
+	_, err = Describe("status: (open")
+	assert.Error(t, err)
+}
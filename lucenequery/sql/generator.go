@@ -1,17 +1,27 @@
 package sql
 
 import (
+	"errors"
 	"fmt"
 	log "github.com/sirupsen/logrus"
 	"github.com/stevejuma/pkg/lucenequery"
+	"math"
+	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 
 // PlaceHolder is the constant value used to indicate a variable substitution
 const PlaceHolder = "?"
 
+// AllFields is the pseudo-field searching all the ToSQLOptions.AllFields e.g. `@all: "foo*"`
+const AllFields = "@all"
+
 var operatorMappings = map[string]string{
 	"eq":       "=",
 	"gt":       ">",
@@ -24,6 +34,9 @@ var operatorMappings = map[string]string{
 	"!~":       "!~",
 	"!~*":      "!~*",
 	"in":       "IN",
+	"all":      "@>",
+	"any":      "&&",
+	"??":       "??",
 	"between":  "BETWEEN",
 	"IMPLICIT": "OR",
 	"AND":      "AND",
@@ -33,24 +46,200 @@ var operatorMappings = map[string]string{
 
 // Fragment a generated sql fragment with args
 type Fragment struct {
+	// Column is the column reported in the generated query columns
 	Column string
-	Term   string
-	Query  string
-	Args   []interface{}
+	// Term is the column expression the value is compared against
+	Term string
+	// Query is a raw SQL predicate used as is in place of the generated comparison,
+	// it is bound with Args which are added to the query args in order
+	Query string
+	Args  []interface{}
+	// Cast is the type the bound values are cast to when compared with the column e.g. `jsonb`
+	// renders `data = CAST(? AS jsonb)`. Casting the values instead of the column keeps ranges
+	// such as `created BETWEEN CAST(? AS timestamptz) and CAST(? AS timestamptz)` index friendly
+	Cast string
+	// Fragments fans the field out across multiple columns, the field
+	// is matched when the value matches any of the fragments
+	Fragments []Fragment
+	// Exists is a correlated subquery for fields of a relationship e.g.
+	// `SELECT 1 FROM comments WHERE comments.post_id = posts.id`, the comparison with the
+	// Term is added to its conditions rendering `EXISTS (SELECT 1 ... AND comments.author = ?)`.
+	// A negated term renders `NOT EXISTS`
+	Exists string
+	// WholeWord matches string values as whole words of the column with the postgres
+	// word boundary regex e.g. `tags ~ ?` bound with `\ygo\y`
+	WholeWord bool
+	// WebSearch matches string values with the postgres full text search of the column, the value is
+	// bound as is to `websearch_to_tsquery` which parses its quoted phrases, `or` and `-` exclusions
+	// e.g. `to_tsvector(body) @@ websearch_to_tsquery(?)`
+	WebSearch bool
+	// DistinctOn are the columns identifying the rows when the field fans the rows out
+	// e.g. through a join, they are reported in Query.DistinctOn
+	DistinctOn []string
+	// Enum maps the value names to the values stored in the column e.g. `status: open`
+	// binds 1 for {"open": 1}. Names missing from the map return ErrUnknownEnum
+	Enum map[string]interface{}
+	// UUID marks a uuid column, the values are cast to uuid unless Cast is set e.g.
+	// `id = CAST(? AS uuid)` so the column index is used. Values which are not UUIDs return ErrInvalidUUID
+	UUID bool
+	// JSONPath is the SQL/JSON path of the field within the jsonb column of the Term, values are matched
+	// by any of the items the path selects e.g. `$.tags[*].name` renders
+	// `jsonb_path_exists(data, ?::jsonpath, jsonb_build_object('v', ?))` bound with `$.tags[*].name ? (@ == $v)`.
+	// The path filter is bound rather than quoted in the query so the query has no literal `?`
+	JSONPath string
+	// Collation is the collation string values are compared with for equality e.g. the postgres ICU
+	// collation `und-u-ks-level2` renders `name = ? COLLATE "und-u-ks-level2"` matching any case
+	Collation string
+	// Range marks a postgres range column with its range type e.g. `daterange`, the `contains` operator
+	// matches the ranges containing the value cast to the range subtype e.g. `period @> CAST(? AS date)`.
+	// The operator returns ErrUnsupportedOperator for the columns without a Range
+	Range string
+}
+
+// rangeSubtypes are the element types of the builtin postgres range types
+var rangeSubtypes = map[string]string{
+	"int4range": "integer",
+	"int8range": "bigint",
+	"numrange":  "numeric",
+	"tsrange":   "timestamp",
+	"tstzrange": "timestamptz",
+	"daterange": "date",
+}
+
+// placeholder returns the bind variable of the fragment values, cast to the fragment type when set
+func (f Fragment) placeholder() string {
+	cast := f.Cast
+	if cast == "" && f.UUID {
+		cast = "uuid"
+	}
+	if cast == "" {
+		return PlaceHolder
+	}
+	return fmt.Sprintf("CAST(%s AS %s)", PlaceHolder, cast)
+}
+
+// castFragment returns the fragment binding the values cast to the `::type` cast of the field e.g.
+// `id::int: "5"` renders `id = CAST(? AS int)`. Casts missing from lucenequery.CastTypes return
+// lucenequery.ErrInvalidCast, the Cast set by the ColumnHandler takes precedence
+func castFragment(fragment Fragment, cast string) (Fragment, error) {
+	if cast == "" {
+		return fragment, nil
+	}
+	cast = strings.ToLower(cast)
+	if !lucenequery.CastTypes[cast] {
+		return fragment, fmt.Errorf("%w: `%s` is not a supported type", lucenequery.ErrInvalidCast, cast)
+	}
+	if fragment.Cast == "" {
+		fragment.Cast = cast
+	}
+	return fragment, nil
+}
+
+// enumValue returns the stored value of the enum names in the value
+func (f Fragment) enumValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, lucenequery.WildCardQuery:
+		return value, nil
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, item := range v {
+			var err error
+			if values[i], err = f.enumValue(item); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	}
+	stored, ok := f.Enum[fmt.Sprintf("%v", value)]
+	if !ok {
+		return nil, fmt.Errorf("%w `%v` for column `%s`", ErrUnknownEnum, value, f.Column)
+	}
+	return stored, nil
+}
+
+// uuidPattern matches the hyphenated hex form of UUIDs
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validateUUID returns an error when the value, or any of the values of a list, is not a UUID
+func (f Fragment) validateUUID(value interface{}) error {
+	switch v := value.(type) {
+	case nil, lucenequery.WildCardQuery:
+		return nil
+	case []interface{}:
+		for _, item := range v {
+			if err := f.validateUUID(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if s, ok := value.(string); !ok || !uuidPattern.MatchString(s) {
+		return fmt.Errorf("%w `%v` for column `%s`", ErrInvalidUUID, value, f.Column)
+	}
+	return nil
 }
 
 // InHandler is a handler for generating in values
 type InHandler func(interface{}) interface{}
 
+// InStrings is an InHandler binding every element of the IN values as a string e.g. `id: [1, "2"]`
+// is bound as ["1", "2"]
+func InStrings(value interface{}) interface{} {
+	values, ok := value.([]interface{})
+	if !ok {
+		return value
+	}
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = fmt.Sprintf("%v", v)
+	}
+	return result
+}
+
+// InInts is an InHandler binding every element of the IN values as an int e.g. `id: [1, "2"]`
+// is bound as [1, 2]. Elements which are not integers are bound as is
+func InInts(value interface{}) interface{} {
+	values, ok := value.([]interface{})
+	if !ok {
+		return value
+	}
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = v
+		switch t := v.(type) {
+		case string:
+			if n, err := strconv.Atoi(strings.TrimSpace(t)); err == nil {
+				result[i] = n
+			}
+		case float64:
+			if t == math.Trunc(t) {
+				result[i] = int(t)
+			}
+		}
+	}
+	return result
+}
+
 // ColumnHandler returns the true expression for the column
 type ColumnHandler func(interface{}) (Fragment, error)
 
+// BindHook transforms an argument before it is bound to the query. The index is the
+// position of the argument in the query args and op the SQL operator it is compared with
+type BindHook func(index int, op string, value interface{}) (interface{}, error)
+
+// RenderHook transforms the final generated query before it is returned e.g. to prefix the tables
+// with a schema or append a clause. The returned query is validated by ToSQLOptions.MaxQueryLength
+type RenderHook func(query Query) (Query, error)
+
 // SearchMode is the mode to apply searches in
 type SearchMode int32
 
 const (
 	SearchModeAny SearchMode = 0
 	SearchModeAll SearchMode = 1
+	// SearchModeAnyExclude matches any of the terms like SearchModeAny, except the negated
+	// terms which are always excluded e.g. `a b -c` is `((a OR b) AND NOT c)`
+	SearchModeAnyExclude SearchMode = 2
 )
 
 // Enum value maps for SearchMode.
@@ -58,10 +247,12 @@ var (
 	SearchModeName = map[int32]string{
 		0: "ANY",
 		1: "ALL",
+		2: "ANY_EXCLUDE",
 	}
 	SearchModeValue = map[string]int32{
-		"ANY": 0,
-		"ALL": 1,
+		"ANY":         0,
+		"ALL":         1,
+		"ANY_EXCLUDE": 2,
 	}
 )
 
@@ -78,6 +269,301 @@ func (x SearchMode) ValueOf(value string) SearchMode {
 }
 
 
+// Placeholder is the style of the bind variables used in a query
+type Placeholder int32
+
+const (
+	// PlaceholderQuestion uses `?` for every bind variable
+	PlaceholderQuestion Placeholder = 0
+	// PlaceholderDollar uses numbered `$1`, `$2` bind variables
+	PlaceholderDollar Placeholder = 1
+	// PlaceholderColon uses numbered `:1`, `:2` bind variables e.g. for Oracle
+	PlaceholderColon Placeholder = 2
+)
+
+// Enum value maps for Placeholder.
+var (
+	PlaceholderName = map[int32]string{
+		0: "QUESTION",
+		1: "DOLLAR",
+		2: "COLON",
+	}
+	PlaceholderValue = map[string]int32{
+		"QUESTION": 0,
+		"DOLLAR":   1,
+		"COLON":    2,
+	}
+)
+
+func (x Placeholder) Number() int32 {
+	return int32(x)
+}
+
+func (x Placeholder) String() string {
+	return PlaceholderName[x.Number()]
+}
+
+func (x Placeholder) ValueOf(value string) Placeholder {
+	return Placeholder(PlaceholderValue[value])
+}
+
+// Dialect is the SQL flavour of the generated queries
+type Dialect int32
+
+const (
+	// DialectStandard renders standard SQL
+	DialectStandard Dialect = 0
+	// DialectPostgres renders PostgreSQL
+	DialectPostgres Dialect = 1
+	// DialectMySQL renders MySQL
+	DialectMySQL Dialect = 2
+	// DialectSQLite renders SQLite
+	DialectSQLite Dialect = 3
+	// DialectClickHouse renders ClickHouse, regex comparisons use `match` and array quantifiers `has`
+	DialectClickHouse Dialect = 4
+	// DialectOracle renders Oracle, regex comparisons use `REGEXP_LIKE`
+	DialectOracle Dialect = 5
+)
+
+// Enum value maps for Dialect.
+var (
+	DialectName = map[int32]string{
+		0: "STANDARD",
+		1: "POSTGRES",
+		2: "MYSQL",
+		3: "SQLITE",
+		4: "CLICKHOUSE",
+		5: "ORACLE",
+	}
+	DialectValue = map[string]int32{
+		"STANDARD":   0,
+		"POSTGRES":   1,
+		"MYSQL":      2,
+		"SQLITE":     3,
+		"CLICKHOUSE": 4,
+		"ORACLE":     5,
+	}
+)
+
+func (x Dialect) Number() int32 {
+	return int32(x)
+}
+
+func (x Dialect) String() string {
+	return DialectName[x.Number()]
+}
+
+func (x Dialect) ValueOf(value string) Dialect {
+	return Dialect(DialectValue[value])
+}
+
+// BooleanLiteral returns the literal of the boolean value in the dialect
+func (x Dialect) BooleanLiteral(value bool) string {
+	switch x {
+	case DialectMySQL, DialectSQLite, DialectOracle:
+		if value {
+			return "1"
+		}
+		return "0"
+	default:
+		if value {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+}
+
+// WrapResult is how the generated query is wrapped in parentheses
+type WrapResult int32
+
+const (
+	// WrapResultDefault keeps the parentheses of the generated query
+	WrapResultDefault WrapResult = 0
+	// WrapResultNever removes the parentheses enclosing the whole query
+	WrapResultNever WrapResult = 1
+	// WrapResultAlways encloses the whole query in parentheses
+	WrapResultAlways WrapResult = 2
+	// WrapResultWhenMultiple encloses the query in parentheses when it joins multiple predicates
+	WrapResultWhenMultiple WrapResult = 3
+)
+
+// Enum value maps for WrapResult.
+var (
+	WrapResultName = map[int32]string{
+		0: "DEFAULT",
+		1: "NEVER",
+		2: "ALWAYS",
+		3: "WHEN_MULTIPLE",
+	}
+	WrapResultValue = map[string]int32{
+		"DEFAULT":       0,
+		"NEVER":         1,
+		"ALWAYS":        2,
+		"WHEN_MULTIPLE": 3,
+	}
+)
+
+func (x WrapResult) Number() int32 {
+	return int32(x)
+}
+
+func (x WrapResult) String() string {
+	return WrapResultName[x.Number()]
+}
+
+func (x WrapResult) ValueOf(value string) WrapResult {
+	return WrapResult(WrapResultValue[value])
+}
+
+// ColumnCase is the casing of the column identifiers of the generated query
+type ColumnCase int32
+
+const (
+	// ColumnCaseDefault keeps the column identifiers as returned by the ColumnHandler
+	ColumnCaseDefault ColumnCase = 0
+	// ColumnCaseLower lowercases the column identifiers
+	ColumnCaseLower ColumnCase = 1
+	// ColumnCaseUpper uppercases the column identifiers
+	ColumnCaseUpper ColumnCase = 2
+)
+
+// Enum value maps for ColumnCase.
+var (
+	ColumnCaseName = map[int32]string{
+		0: "DEFAULT",
+		1: "LOWER",
+		2: "UPPER",
+	}
+	ColumnCaseValue = map[string]int32{
+		"DEFAULT": 0,
+		"LOWER":   1,
+		"UPPER":   2,
+	}
+)
+
+func (x ColumnCase) Number() int32 {
+	return int32(x)
+}
+
+func (x ColumnCase) String() string {
+	return ColumnCaseName[x.Number()]
+}
+
+func (x ColumnCase) ValueOf(value string) ColumnCase {
+	return ColumnCase(ColumnCaseValue[value])
+}
+
+// Apply returns the identifier in the casing, quoted strings and identifiers such as the
+// keys of JSON paths `labels->>'Env'` are kept as is
+func (x ColumnCase) Apply(identifier string) string {
+	convert := strings.ToLower
+	switch x {
+	case ColumnCaseLower:
+	case ColumnCaseUpper:
+		convert = strings.ToUpper
+	default:
+		return identifier
+	}
+	var sb strings.Builder
+	var quote rune
+	start := 0
+	for i, r := range identifier {
+		switch {
+		case quote == 0 && (r == '\'' || r == '"'):
+			sb.WriteString(convert(identifier[start:i]))
+			quote, start = r, i
+		case quote != 0 && r == quote:
+			sb.WriteString(identifier[start : i+1])
+			quote, start = 0, i+1
+		}
+	}
+	if quote != 0 {
+		sb.WriteString(identifier[start:])
+	} else {
+		sb.WriteString(convert(identifier[start:]))
+	}
+	return sb.String()
+}
+
+// OperatorPolicy is how the generator handles a term operator it has no SQL mapping for
+type OperatorPolicy int32
+
+const (
+	// OperatorPolicyFallback compares the value with `=`
+	OperatorPolicyFallback OperatorPolicy = 0
+	// OperatorPolicyWarn logs a warning and compares the value with `=`
+	OperatorPolicyWarn OperatorPolicy = 1
+	// OperatorPolicyError fails the generation with ErrUnknownOperator
+	OperatorPolicyError OperatorPolicy = 2
+)
+
+// Enum value maps for OperatorPolicy.
+var (
+	OperatorPolicyName = map[int32]string{
+		0: "FALLBACK",
+		1: "WARN",
+		2: "ERROR",
+	}
+	OperatorPolicyValue = map[string]int32{
+		"FALLBACK": 0,
+		"WARN":     1,
+		"ERROR":    2,
+	}
+)
+
+func (x OperatorPolicy) Number() int32 {
+	return int32(x)
+}
+
+func (x OperatorPolicy) String() string {
+	return OperatorPolicyName[x.Number()]
+}
+
+func (x OperatorPolicy) ValueOf(value string) OperatorPolicy {
+	return OperatorPolicy(OperatorPolicyValue[value])
+}
+
+// ErrQueryTooLong is returned when the generated SQL is longer than ToSQLOptions.MaxQueryLength
+var ErrQueryTooLong = errors.New("generated query too long")
+
+// ErrMaxDepthExceeded is returned when the boolean expressions of the query are nested
+// deeper than ToSQLOptions.MaxDepth
+var ErrMaxDepthExceeded = errors.New("max depth exceeded")
+
+// ErrInvalidQuery is returned by Query.Validate for malformed queries
+var ErrInvalidQuery = errors.New("invalid query")
+
+// ErrUnknownOperator is returned for a term operator without a SQL mapping when
+// ToSQLOptions.OnUnknownOperator is OperatorPolicyError
+var ErrUnknownOperator = errors.New("unknown operator")
+
+// ErrUnsupportedOperator is returned for a term operator the ToSQLOptions.Dialect cannot render
+var ErrUnsupportedOperator = errors.New("unsupported operator")
+
+// ErrInvalidUUID is returned for a value of a Fragment.UUID column which is not a UUID
+var ErrInvalidUUID = errors.New("invalid uuid")
+
+// ErrUnknownEnum is returned for a value which is not one of the names of Fragment.Enum
+var ErrUnknownEnum = errors.New("unknown enum value")
+
+// WildcardChars are the wildcards of the LIKE patterns generated for wildcard queries
+type WildcardChars struct {
+	// Any matches any sequence of characters, `%` by default
+	Any string
+	// Single matches a single character, `_` by default
+	Single string
+	// Escape escapes the wildcards in the values, `\` by default. A custom escape character
+	// is declared with an `ESCAPE` clause e.g. `name LIKE '?%' ESCAPE '!'` for values with backslashes
+	Escape string
+}
+
+// SoftDelete excludes the soft deleted rows of a table from the generated queries
+type SoftDelete struct {
+	// Column is the deletion timestamp of the rows e.g. `deleted_at`, rows are excluded
+	// unless it is null
+	Column string
+}
+
 // ToSQLOptions specifies properties for the ToSQL function
 type ToSQLOptions struct {
 	// Default field is the default column to use for filtering when not defined
@@ -87,16 +573,217 @@ type ToSQLOptions struct {
 	// and by default - will be interpreted as "OR NOT"
 	// SearchMode `ALL` increases the precision of queries by including fewer results,
 	// and by default - will be interpreted as "AND NOT"
+	// SearchMode `ANY_EXCLUDE` includes the results of any of the terms, except the results
+	// of the negated terms which are excluded e.g. `a -b` is "a AND NOT b".
+	// An explicit operator is kept for the negated terms e.g. `a AND -b` is "a AND NOT b" in every mode
 	SearchMode SearchMode
+	// Dialect is the SQL flavour of the generated query
+	Dialect Dialect
+	// InlineBooleans renders boolean values as literals of the Dialect e.g. `available = TRUE`
+	// instead of binding them
+	InlineBooleans bool
+	// BooleanTests compares boolean values with the three-valued `IS TRUE`/`IS FALSE` tests
+	// e.g. `-active: true` renders `active IS NOT TRUE` which also matches NULL values
+	BooleanTests bool
+	// AllFields are the fields searched by the `@all` pseudo-field, the value
+	// matches when it matches any of the fields. A quoted value starting or ending with `*`
+	// is a wildcard as typed in a search box e.g. `@all: "foo*"` is `@all: foo*`
+	AllFields []string
+	// JSONColumns are the JSON columns whose keys are filtered with dotted fields e.g. `labels.env: prod`
+	// filters `labels->>'env' = ?`. Ranges with numeric bounds cast the value to numeric
+	JSONColumns []string
+	// Schema is a struct, or its reflect.Type, whose tagged fields are the fields that can be filtered
+	// when no ColumnHandler is set. The `json` tag is the name of the field and the `db` tag its column,
+	// either tag is used for both when the other is missing. Fields without a tag are not allowed
+	Schema interface{}
+	// FieldAliases renames query fields before they are passed to the ColumnHandler
+	// e.g. {"author": "created_by"} filters `author: peter` on the created_by column
+	FieldAliases map[string]string
+	// GroupBy collects the values of the `group` and `groupby` pseudo-fields e.g. `groupby:category`
+	// into Query.GroupBy instead of filtering on them. The values are resolved with the ColumnHandler
+	GroupBy bool
+	// DistinctColumns removes the predicates of a boolean group that are exact duplicates
+	// of an earlier predicate in the group e.g. `status:open status:closed status:open`, the nested
+	// groups of the same operator are flattened into one group
+	DistinctColumns bool
+	// PrefixAsRange renders prefix wildcards (`name: abc*`) as an index friendly
+	// range `(name >= 'abc' AND name < 'abd')` instead of LIKE
+	PrefixAsRange bool
+	// WildcardChars overrides the `%` and `_` LIKE wildcards for dialects using other
+	// characters and the escape character. When set the wildcards in the values are escaped
+	WildcardChars WildcardChars
+	// SoftDelete adds `deleted_at IS NULL` for the SoftDelete.Column to every generated query, unless
+	// the query filters on the column itself e.g. `deleted_at: *` to search the deleted rows
+	SoftDelete SoftDelete
+	// Boosts are the weights of the fields in the ToScoreSQL score, 1 by default
+	Boosts map[string]float64
+	// MinimumShouldMatch requires at least the given number of the clauses of OR groups to match,
+	// the matching clauses are counted with `CASE WHEN` expressions
+	MinimumShouldMatch int
+	// WrapResult controls the parentheses enclosing the generated query
+	WrapResult WrapResult
+	// ColumnCase lowercases or uppercases the column identifiers once resolved by the ColumnHandler,
+	// the values are not changed
+	ColumnCase ColumnCase
+	// Pretty formats the generated query across indented lines, one predicate per line
+	Pretty bool
+	// MaxQueryLength is the maximum length of the generated SQL, no limit when 0
+	MaxQueryLength int
+	// MaxDepth is the maximum nesting of the boolean expressions of the query, no limit when 0.
+	// A sequence of terms is nested by the parser e.g. `a AND b AND c` has a depth of 2
+	MaxDepth int
+	// TrimValues strips the leading and trailing whitespace of the string values bound to the
+	// query, the LIKE patterns of wildcard values are kept as is unless TrimPatterns is set
+	TrimValues   bool
+	TrimPatterns bool
+	// Now returns the current time the relative time keywords such as `today` are resolved with,
+	// defaults to time.Now
+	Now func() time.Time
+	// Location converts the time values bound to the query, including the resolved relative
+	// time keywords, to the location e.g. time.UTC. Time values are bound as is when nil
+	Location *time.Location
+	// OnColumn is called with the field and SQL operator of every term and range of the query
+	// e.g. to record metrics of the filtered fields, it does not change the generated query
+	OnColumn func(field, op string)
+	// BindHook is called for every argument bound to the query e.g. to encrypt values
+	BindHook BindHook
+	// RenderHook is called with the final query, after it is cleaned, wrapped and formatted
+	RenderHook RenderHook
+	// OnUnknownOperator is the policy for term operators without a SQL mapping,
+	// by default the value is compared with `=`
+	OnUnknownOperator OperatorPolicy
+	// FloatEpsilon compares float values within the epsilon instead of exactly when set
+	// e.g. `price: 19.99` renders `ABS(price - ?) < 0.001` for 0.001
+	FloatEpsilon float64
+	// SkipEmptyStrings removes the terms matching an empty string e.g. `name: ""` submitted for
+	// a blank form field. A required empty string such as `name:(+"")` is kept
+	SkipEmptyStrings bool
+	// Flatten replaces the boolean expressions of a single argument with their argument before
+	// generating the query, so the `((status: open))` of a tree built in code renders `status = ?`
+	Flatten bool
+	// ExpandInPlaceholders binds every value of an IN expression with its own placeholder
+	// e.g. `status IN (?, ?)` instead of binding the list to a single placeholder, for database/sql
+	// drivers which do not expand slices. Slices returned by the InHandler are flattened into the args
+	ExpandInPlaceholders bool
+	// InChunkSize splits IN lists with more values into IN expressions of at most InChunkSize values
+	// e.g. `(id IN (?) OR id IN (?))` for databases limiting the size of lists, no limit when 0
+	InChunkSize int
+	// InChunkJoin is the operator joining the IN chunks, `OR` by default. Negated lists render
+	// `NOT IN` chunks joined with the opposite operator e.g. `(id NOT IN (?) AND id NOT IN (?))`
+	InChunkJoin string
 	InHandler
 	ColumnHandler
 }
 
 // Query is the generated query
 type Query struct {
+	// Query is the generated SQL, also returned by String
 	Query   string
 	Args    []interface{}
 	Columns []string
+	// GroupBy are the columns of the `group`/`groupby` pseudo-fields when ToSQLOptions.GroupBy is set
+	GroupBy []string
+	// DistinctOn are the columns of the Fragment.DistinctOn of the matched fields, for
+	// deduplicating the rows of joins with `SELECT DISTINCT ON (...)`
+	DistinctOn []string
+	// template is the query with the inlined values bound, see Template
+	template string
+}
+
+// String returns the generated SQL e.g. for printing the query with fmt
+func (q Query) String() string {
+	return q.Query
+}
+
+// WhereClause returns the predicate and args of the query for use as the conditions of an ORM
+// e.g. `clause, args := q.WhereClause(); db.Where(clause, args...)`. The parentheses enclosing the whole predicate are removed,
+// an empty string and nil args are returned for an empty query
+func (q Query) WhereClause() (string, []interface{}) {
+	clause := unwrap(strings.TrimSpace(q.Query))
+	if clause == "" {
+		return "", nil
+	}
+	return clause, append([]interface{}{}, q.Args...)
+}
+
+// Rebind returns a copy of the query with the `?` bind variables replaced by the
+// given placeholder style. Bind variables are numbered in the order of the query args
+func (q Query) Rebind(placeholder Placeholder) Query {
+	rebound := q
+	rebound.Args = append([]interface{}{}, q.Args...)
+	rebound.Columns = append([]string{}, q.Columns...)
+	if q.GroupBy != nil {
+		rebound.GroupBy = append([]string{}, q.GroupBy...)
+	}
+	if q.DistinctOn != nil {
+		rebound.DistinctOn = append([]string{}, q.DistinctOn...)
+	}
+	if placeholder == PlaceholderQuestion {
+		return rebound
+	}
+	var sb strings.Builder
+	n := 0
+	for _, r := range q.Query {
+		if string(r) != PlaceHolder {
+			sb.WriteRune(r)
+			continue
+		}
+		n++
+		switch placeholder {
+		case PlaceholderDollar:
+			sb.WriteString(fmt.Sprintf("$%d", n))
+		case PlaceholderColon:
+			sb.WriteString(fmt.Sprintf(":%d", n))
+		}
+	}
+	rebound.Query = sb.String()
+	return rebound
+}
+
+// Template returns the generated query with the values inlined by ToSQLOptions.InlineBooleans replaced
+// by a `?` e.g. for use as the statement of a tracing span. The user values of the query are otherwise bound,
+// so the bind variables and the constants of the generated SQL and fragments such as `1 = 0` are kept as is.
+// The template keeps the `?` bind variables of the generated query when it is rebound
+func (q Query) Template() string {
+	if q.template == "" {
+		return q.Query
+	}
+	return q.template
+}
+
+// trailingOperator matches a boolean operator left dangling at the start or end of a query or group
+var trailingOperator = regexp.MustCompile(`(?i)(^|\()\s*(AND|OR)\b|\b(AND|OR|NOT)\s*($|\))`)
+
+// Validate checks that the parentheses of the query are balanced, there is a bind variable
+// for every arg and no boolean operator is left dangling at the start or end of the query or a group
+func (q Query) Validate() error {
+	depth, quoted, placeholders := 0, false, 0
+	for _, r := range q.Query {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case string(r) == PlaceHolder:
+			placeholders++
+		case quoted:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth < 0 {
+				return fmt.Errorf("%w: unbalanced parentheses in `%s`", ErrInvalidQuery, q.Query)
+			}
+		}
+	}
+	if depth != 0 {
+		return fmt.Errorf("%w: unbalanced parentheses in `%s`", ErrInvalidQuery, q.Query)
+	}
+	if placeholders != len(q.Args) {
+		return fmt.Errorf("%w: %d bind variables for %d args in `%s`", ErrInvalidQuery, placeholders, len(q.Args), q.Query)
+	}
+	if m := trailingOperator.FindString(strings.TrimSpace(q.Query)); m != "" {
+		return fmt.Errorf("%w: dangling operator `%s` in `%s`", ErrInvalidQuery, strings.Trim(m, "() "), q.Query)
+	}
+	return nil
 }
 
 var regexes = []struct {
@@ -106,10 +793,44 @@ var regexes = []struct {
 	{Pattern: regexp.MustCompile(`^\s*(AND|OR)\s+([^()]+)(AND|OR)`), Replace: "$2$1"},
 	{Pattern: regexp.MustCompile(`^\s*(AND|OR)\s*([^()]+)$`), Replace: "$2"},
 	{Pattern: regexp.MustCompile(`("[^"]+").""`), Replace: "$1"},
+	{Pattern: regexp.MustCompile(`^\s*(AND|OR)\s+(NOT\s+(EXISTS\s+)?\()`), Replace: "$2"},
+	{Pattern: regexp.MustCompile(`^\s*(AND|OR)\s+\(`), Replace: "("},
+	{Pattern: regexp.MustCompile(`^\s*(AND|OR)\s+(NOT\s)`), Replace: "$2"},
 }
 
-// ToSQL returns the query as SQL string
-func ToSQL(filter interface{}, opt *ToSQLOptions) (Query, error) {
+// Visitor renders the nodes of a parsed query to SQL
+type Visitor interface {
+	// VisitBoolean renders a boolean expression and its arguments
+	VisitBoolean(node lucenequery.BooleanExpression) (Query, error)
+	// VisitTerm renders a term query
+	VisitTerm(node lucenequery.TermQuery) (Query, error)
+	// VisitRange renders a range query
+	VisitRange(node lucenequery.RangeQuery) (Query, error)
+	// VisitWildcard renders the wildcard value of a term query against the column
+	VisitWildcard(column string, node lucenequery.TermQuery, wildcard lucenequery.WildCardQuery) (Query, error)
+}
+
+// Generator is the default Visitor used by ToSQL. Embed it in a type that overrides
+// one of the Visit methods and assign that type to Visitor to customise the output
+type Generator struct {
+	// Visitor is used to render child nodes, defaults to the generator itself
+	Visitor    Visitor
+	opt        *ToSQLOptions
+	args       int
+	depth      int
+	distinctOn []string
+	// inlined are the predicates rendered with an inlined value and their bound form
+	inlined [][2]string
+}
+
+// NewGenerator returns a generator for the given options
+func NewGenerator(opt *ToSQLOptions) *Generator {
+	if opt == nil {
+		opt = &ToSQLOptions{}
+	}
+	if opt.ColumnHandler == nil && opt.Schema != nil {
+		opt.ColumnHandler = schemaHandler(opt.Schema)
+	}
 	if opt.ColumnHandler == nil {
 		opt.ColumnHandler = func(field interface{}) (Fragment, error) {
 			switch f := field.(type) {
@@ -122,230 +843,1616 @@ func ToSQL(filter interface{}, opt *ToSQLOptions) (Query, error) {
 			}
 		}
 	}
-	query, err := renderSQL(filter, opt)
+	g := &Generator{opt: opt}
+	g.Visitor = g
+	return g
+}
+
+// schemaHandler returns a ColumnHandler allowing only the tagged fields of the schema struct
+func schemaHandler(schema interface{}) ColumnHandler {
+	t, ok := schema.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(schema)
+	}
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	columns := map[string]string{}
+	if t != nil && t.Kind() == reflect.Struct {
+		schemaColumns(t, columns)
+	}
+	return func(field interface{}) (Fragment, error) {
+		var name string
+		switch f := field.(type) {
+		case lucenequery.RangeQuery:
+			name = f.Term
+		case lucenequery.TermQuery:
+			name = f.Term
+		default:
+			return Fragment{}, fmt.Errorf("unknown type: %T", f)
+		}
+		column, ok := columns[name]
+		if !ok {
+			return Fragment{}, fmt.Errorf("unknown field `%s`", name)
+		}
+		return Fragment{Term: column, Column: column}, nil
+	}
+}
+
+// schemaColumns adds the field names and columns of the tagged struct fields, including embedded structs
+func schemaColumns(t reflect.Type, columns map[string]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, column := tagName(f.Tag.Get("json")), tagName(f.Tag.Get("db"))
+		if f.Anonymous && name == "" && column == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				schemaColumns(ft, columns)
+			}
+			continue
+		}
+		if name == "" {
+			name = column
+		}
+		if column == "" {
+			column = name
+		}
+		if name != "" {
+			columns[name] = column
+		}
+	}
+}
+
+// tagName returns the name of a struct tag value, ignoring its options
+func tagName(tag string) string {
+	name := strings.Split(tag, ",")[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// ToSQL returns the query as SQL string
+func ToSQL(filter interface{}, opt *ToSQLOptions) (Query, error) {
+	return NewGenerator(opt).Generate(filter)
+}
+
+// Generate returns the filter as SQL string
+func (g *Generator) Generate(filter interface{}) (Query, error) {
+	g.args, g.depth, g.distinctOn, g.inlined = 0, 0, nil, nil
+	var groupBy []string
+	if v, ok := filter.(string); ok && (g.opt.GroupBy || g.opt.SkipEmptyStrings || g.opt.Flatten) {
+		var err error
+		if filter, err = g.parse(v); err != nil {
+			return Query{Query: "", Args: []interface{}{}, Columns: []string{}}, err
+		}
+	}
+	if g.opt.Flatten {
+		filter = lucenequery.Flatten(filter)
+	}
+	if g.opt.GroupBy {
+		var err error
+		if filter, groupBy, err = g.groupBy(filter); err != nil {
+			return Query{Query: "", Args: []interface{}{}, Columns: []string{}}, err
+		}
+		if nodes, ok := filter.([]interface{}); filter == nil || ok && len(nodes) == 0 {
+			// a query of group fields only has no conditions
+			return Query{Query: "", Args: []interface{}{}, Columns: []string{}, GroupBy: groupBy}, nil
+		}
+	}
+	if g.opt.SkipEmptyStrings {
+		var ok bool
+		if filter, ok = skipEmptyStrings(filter); !ok {
+			return Query{Query: "", Args: []interface{}{}, Columns: []string{}}, nil
+		}
+	}
+	query, err := g.Visit(filter)
 	if err != nil {
 		return query, err
 	}
+	query.GroupBy = groupBy
+	query.DistinctOn = g.distinctOn
 	log.WithFields(log.Fields{
 		"filter":  filter,
-		"options": opt,
+		"options": g.opt,
 		"sql":     query.Query,
 	}).Debug("SQL generated")
-	query.Query = cleanExpr(query.Query)
+	query.Query = wrap(g.softDelete(cleanExpr(query.Query), query.Columns), g.opt.WrapResult)
+	if g.opt.Pretty {
+		query.Query = pretty(query.Query, "")
+	}
+	if g.opt.RenderHook != nil {
+		if query, err = g.opt.RenderHook(query); err != nil {
+			return Query{Query: "", Args: []interface{}{}, Columns: []string{}}, err
+		}
+	}
+	if g.opt.MaxQueryLength > 0 && len(query.Query) > g.opt.MaxQueryLength {
+		return Query{Query: "", Args: []interface{}{}, Columns: []string{}},
+			fmt.Errorf("%w: %d characters generated, limit is %d", ErrQueryTooLong, len(query.Query), g.opt.MaxQueryLength)
+	}
+	if len(g.inlined) > 0 {
+		query.template = g.template(query.Query)
+	}
 	return query, err
 }
 
-func cleanExpr(expr string) string {
-	for _, r := range regexes {
-		expr = r.Pattern.ReplaceAllString(expr, r.Replace)
+// template returns the query with the inlined predicates replaced by their bound form,
+// the predicates are replaced in the order they were rendered
+func (g *Generator) template(query string) string {
+	var sb strings.Builder
+	for _, p := range g.inlined {
+		i := strings.Index(query, p[0])
+		if i < 0 {
+			continue
+		}
+		sb.WriteString(query[:i] + p[1])
+		query = query[i+len(p[0]):]
 	}
-	return strings.TrimSpace(expr)
+	sb.WriteString(query)
+	return sb.String()
 }
 
-func renderSQL(filter interface{}, opt *ToSQLOptions) (Query, error) {
-	var query, cache = Query{Query: "", Args: []interface{}{}, Columns: []string{}}, map[string]string{}
-	switch v := filter.(type) {
-	case []interface{}:
-		for _, r := range v {
-			q, err := renderSQL(r, opt)
-			if err != nil {
-				return query, err
-			}
-			for _, t := range q.Columns {
-				if _, ok := cache[t]; !ok {
-					query.Columns = append(query.Columns, t)
-				}
-			}
-			query.Args = append(query.Args, q.Args...)
-			query.Query += q.Query
+// softDelete returns the expression excluding the soft deleted rows when ToSQLOptions.SoftDelete
+// is set and none of the columns of the expression is the soft delete column
+func (g *Generator) softDelete(expr string, columns []string) string {
+	column := g.opt.ColumnCase.Apply(g.opt.SoftDelete.Column)
+	if column == "" {
+		return expr
+	}
+	for _, c := range columns {
+		if c == column {
+			return expr
 		}
-		query.Query = cleanExpr(query.Query)
-		return query, nil
-	case string:
-		dsl, err := lucenequery.Parse("ToSQL", []byte(v))
-		if err != nil {
+	}
+	expr = unwrap(strings.TrimSpace(expr))
+	if expr == "" {
+		return fmt.Sprintf("%s IS NULL", column)
+	}
+	if multiple(expr) {
+		expr = fmt.Sprintf("(%s)", expr)
+	}
+	return fmt.Sprintf("%s AND %s IS NULL", expr, column)
+}
+
+// ToScoreSQL returns a SQL expression scoring the rows by the clauses of the filter they
+// match e.g. for ranking the results with `ORDER BY score DESC`. Every matching term and range
+// adds the ToSQLOptions.Boosts weight of its field, 1 by default. Negated clauses are not scored
+func ToScoreSQL(filter interface{}, opt *ToSQLOptions) (Query, error) {
+	return NewGenerator(opt).Score(filter)
+}
+
+// Score returns the filter as a SQL expression summing the weights of the matching clauses
+func (g *Generator) Score(filter interface{}) (Query, error) {
+	var query, exprs = Query{Query: "", Args: []interface{}{}, Columns: []string{}}, []string{}
+	g.args = 0
+	if v, ok := filter.(string); ok {
+		var err error
+		if filter, err = g.parse(v); err != nil {
 			return query, err
 		}
-		log.WithFields(log.Fields{
-			"query":  v,
-			"dsl":     dsl,
-		}).Debug("Parsed Query")
-		return renderSQL(dsl, opt)
-	case lucenequery.BooleanExpression:
-		size := len(v.Args)
-		for i, r := range v.Args {
-			q, err := renderSQL(r, opt)
-			op := operatorMappings[v.Op]
-			if v.Op == "IMPLICIT" && opt.SearchMode == SearchModeAll {
-				op = "AND"
-			}
-			if op == "" {
-				op = "OR"
-			}
-			if op == "NOT" {
-				if opt.SearchMode == SearchModeAny {
-					op = "OR NOT"
-				} else {
-					op = "AND NOT"
+	}
+	var score func(node interface{}) error
+	score = func(node interface{}) error {
+		var field string
+		switch v := node.(type) {
+		case []interface{}:
+			for _, n := range v {
+				if err := score(n); err != nil {
+					return err
 				}
 			}
-
-			if err != nil {
-				return q, err
+			return nil
+		case lucenequery.BooleanExpression:
+			args := v.Args
+			if v.Op == "NOT" && len(args) == 1 {
+				return nil
+			} else if v.Op == "NOT" {
+				// the arguments following the first argument of NOT are excluded
+				args = args[:1]
 			}
-			if i > 0 && i < size {
-				if m, _ := regexp.MatchString(`^\s*(AND|OR|NOT)`, q.Query); !m {
-					query.Query += fmt.Sprintf(" %s ", op)
-				}
+			return score(args)
+		case lucenequery.TermQuery:
+			if v.Prefix == "-" {
+				return nil
 			}
-			for _, t := range q.Columns {
-				if _, ok := cache[t]; !ok {
-					query.Columns = append(query.Columns, t)
-				}
+			v.Prefix = ""
+			field, node = v.Term, v
+		case lucenequery.RangeQuery:
+			field = v.Term
+		}
+		q, err := g.Visit(node)
+		if err != nil {
+			return err
+		}
+		weight, ok := g.opt.Boosts[field]
+		if !ok {
+			weight = 1
+		}
+		exprs = append(exprs, fmt.Sprintf("CASE WHEN %s THEN %s ELSE 0 END", strings.TrimSpace(q.Query), strconv.FormatFloat(weight, 'g', -1, 64)))
+		query.Args = append(query.Args, q.Args...)
+		query.Columns = appendColumns(query.Columns, q.Columns...)
+		return nil
+	}
+	if err := score(filter); err != nil {
+		return Query{Query: "", Args: []interface{}{}, Columns: []string{}}, err
+	}
+	if len(exprs) == 0 {
+		query.Query = "0"
+		return query, nil
+	}
+	query.Query = fmt.Sprintf("(%s)", strings.Join(exprs, " + "))
+	return query, nil
+}
+
+// parse returns the parsed query string
+func (g *Generator) parse(query string) (interface{}, error) {
+	dsl, err := lucenequery.Parse("ToSQL", []byte(query))
+	if err != nil {
+		return dsl, err
+	}
+	log.WithFields(log.Fields{
+		"query": query,
+		"dsl":   dsl,
+	}).Debug("Parsed Query")
+	return dsl, nil
+}
+
+// Visit renders the filter which is either a query string, a parsed query node or a list of nodes
+func (g *Generator) Visit(filter interface{}) (Query, error) {
+	query := Query{Query: "", Args: []interface{}{}, Columns: []string{}}
+	switch v := filter.(type) {
+	case []interface{}:
+		for _, r := range v {
+			q, err := g.Visit(r)
+			if err != nil {
+				return query, err
 			}
-			query.Query += q.Query
+			query.Columns = appendColumns(query.Columns, q.Columns...)
 			query.Args = append(query.Args, q.Args...)
+			query.Query += q.Query
 		}
-		query.Query = fmt.Sprintf("(%s)", strings.TrimSpace(cleanExpr(query.Query)))
+		query.Query = cleanExpr(query.Query)
 		return query, nil
-	case lucenequery.TermQuery:
-		fragment, err := opt.ColumnHandler(v)
+	case string:
+		dsl, err := g.parse(v)
 		if err != nil {
-			log.WithFields(log.Fields{
-				"term": v.Term,
-				"sql":  query.Query,
-			}).Errorf("unknown column `%s`", v.Term)
-			return query, fmt.Errorf("invalid column: `%s` error: %s", v.Term, err)
-		}
-		if fragment.Column != "" {
-			query.Columns = append(query.Columns, fragment.Column)
-		}
-		if fragment.Query != "" {
-			query.Query = fragment.Query
-			query.Args = fragment.Args
+			return query, err
+		}
+		return g.Visit(dsl)
+	case map[string]interface{}:
+		node := mapFilter(v)
+		if node == nil {
 			return query, nil
 		}
-		term := fragment.Term
-		if term == "" {
-			if opt != nil && opt.DefaultField != "" {
-				term = opt.DefaultField
+		return g.Visit(node)
+	case lucenequery.BooleanExpression:
+		g.depth++
+		defer func() { g.depth-- }()
+		if g.opt.MaxDepth > 0 && g.depth > g.opt.MaxDepth {
+			return query, fmt.Errorf("%w: boolean expressions nested deeper than %d", ErrMaxDepthExceeded, g.opt.MaxDepth)
+		}
+		return g.Visitor.VisitBoolean(v)
+	case lucenequery.TermQuery:
+		v.Value = g.resolveTime(v.Value)
+		g.onColumn(v.Term, termOperator(v))
+		query, err := g.Visitor.VisitTerm(v)
+		return g.bind(termOperator(v), query, err)
+	case lucenequery.RangeQuery:
+		v.Min, v.Max = g.resolveTime(v.Min), g.resolveTime(v.Max)
+		op, _ := v.Kind()
+		g.onColumn(v.Term, operatorMappings[op])
+		query, err := g.Visitor.VisitRange(v)
+		return g.bind(operatorMappings[op], query, err)
+	default:
+		return query, fmt.Errorf("unknown type: `%T`", v)
+	}
+}
+
+// VisitBoolean renders a boolean expression and its arguments,
+// a NOT expression with a single argument negates the argument
+func (g *Generator) VisitBoolean(v lucenequery.BooleanExpression) (Query, error) {
+	var query, opt = Query{Query: "", Args: []interface{}{}, Columns: []string{}}, g.opt
+	if v.Op == "NOT" && len(v.Args) == 1 {
+		q, err := g.Visit(v.Args[0])
+		if err != nil {
+			return q, err
+		}
+		q.Query = prefixExpr("-", strings.TrimSpace(q.Query), opt)
+		return q, nil
+	}
+	if opt.SearchMode == SearchModeAnyExclude && (v.Op == "IMPLICIT" || v.Op == "OR") {
+		if node, ok := excludeNegations(v); ok {
+			return g.Visit(node)
+		}
+	}
+	if clauses, ok := g.shouldClauses(v); ok {
+		return g.minimumShouldMatch(clauses)
+	}
+	if opt.DistinctColumns && v.Op != "NOT" {
+		// duplicates are removed from the whole sequence e.g. `a b a`
+		v.Args = sequence(v)
+	}
+	size, seen := len(v.Args), map[string]bool{}
+	for i, r := range v.Args {
+		q, err := g.Visit(r)
+		if err == nil && opt.DistinctColumns && v.Op != "NOT" {
+			key := fmt.Sprintf("%s %#v", strings.TrimSpace(q.Query), q.Args)
+			if seen[key] {
+				g.args -= len(q.Args)
+				continue
+			}
+			seen[key] = true
+		}
+		op := operatorMappings[v.Op]
+		if v.Op == "IMPLICIT" && opt.SearchMode == SearchModeAll {
+			op = "AND"
+		}
+		if op == "" {
+			op = "OR"
+		}
+		if op == "NOT" {
+			if opt.SearchMode == SearchModeAny {
+				op = "OR NOT"
 			} else {
-				return query, fmt.Errorf("invalid term value `%v` provided for term without a name", v.Value)
+				op = "AND NOT"
 			}
 		}
-		op := "="
-		if v.Op != "" {
-			if v, ok := operatorMappings[v.Op]; ok {
-				op = v
+
+		if err != nil {
+			return q, err
+		}
+		if (v.Op == "AND" || v.Op == "OR") && negated(r) {
+			// the explicit operator joins the negated argument rather than the search mode
+			q.Query = fmt.Sprintf(" %s %s", v.Op, leadingJoiner.ReplaceAllString(q.Query, ""))
+		}
+		if i > 0 && i < size {
+			if m, _ := regexp.MatchString(`^\s*(AND|OR|NOT)`, q.Query); !m {
+				query.Query += fmt.Sprintf(" %s ", op)
 			}
 		}
-		query.Query = fmt.Sprintf("%s %s %s", term, op, PlaceHolder)
+		query.Columns = appendColumns(query.Columns, q.Columns...)
+		query.Query += q.Query
+		query.Args = append(query.Args, q.Args...)
+	}
+	query.Query = fmt.Sprintf("(%s)", strings.TrimSpace(cleanExpr(query.Query)))
+	return query, nil
+}
+
+// leadingJoiner matches the operator joining a prefixed argument with the preceding arguments
+var leadingJoiner = regexp.MustCompile(`^\s*(AND|OR)\s+`)
+
+// negated reports whether the node is negated by the - prefix or a NOT with a single argument
+func negated(node interface{}) bool {
+	switch v := node.(type) {
+	case lucenequery.TermQuery:
+		return v.Prefix == "-"
+	case lucenequery.BooleanExpression:
+		return v.Op == "NOT" && len(v.Args) == 1
+	case *lucenequery.BooleanExpression:
+		return v != nil && v.Op == "NOT" && len(v.Args) == 1
+	}
+	return false
+}
+
+// shouldClauses returns the optional clauses of an OR group when a minimum should match is configured,
+// nested groups with the same operator are flattened. Groups with prefixed terms are not supported
+func (g *Generator) shouldClauses(v lucenequery.BooleanExpression) ([]interface{}, bool) {
+	if g.opt.MinimumShouldMatch < 2 || !(v.Op == "OR" || v.Op == "IMPLICIT" && g.opt.SearchMode != SearchModeAll) {
+		return nil, false
+	}
+	var clauses []interface{}
+	for _, arg := range v.Args {
+		switch t := arg.(type) {
+		case lucenequery.BooleanExpression:
+			if t.Op == v.Op {
+				nested, ok := g.shouldClauses(t)
+				if !ok {
+					return nil, false
+				}
+				clauses = append(clauses, nested...)
+				continue
+			}
+		case lucenequery.TermQuery:
+			if t.Prefix != "" {
+				return nil, false
+			}
+		}
+		clauses = append(clauses, arg)
+	}
+	return clauses, true
+}
+
+// minimumShouldMatch renders the clauses as a sum of the matching clauses which must be at least
+// ToSQLOptions.MinimumShouldMatch e.g. `(CASE WHEN a = ? THEN 1 ELSE 0 END + ...) >= 2`
+func (g *Generator) minimumShouldMatch(clauses []interface{}) (Query, error) {
+	var query, exprs = Query{Query: "", Args: []interface{}{}, Columns: []string{}}, []string{}
+	for _, clause := range clauses {
+		q, err := g.Visit(clause)
+		if err != nil {
+			return q, err
+		}
+		exprs = append(exprs, fmt.Sprintf("CASE WHEN %s THEN 1 ELSE 0 END", strings.TrimSpace(q.Query)))
+		query.Args = append(query.Args, q.Args...)
+		query.Columns = appendColumns(query.Columns, q.Columns...)
+	}
+	if len(clauses) < g.opt.MinimumShouldMatch {
+		g.args -= len(query.Args)
+		query.Query, query.Args = "1 = 0", []interface{}{}
+		return query, nil
+	}
+	query.Query = fmt.Sprintf("((%s) >= %d)", strings.Join(exprs, " + "), g.opt.MinimumShouldMatch)
+	return query, nil
+}
+
+// VisitTerm renders a term query
+func (g *Generator) VisitTerm(v lucenequery.TermQuery) (Query, error) {
+	query := Query{Query: "", Args: []interface{}{}, Columns: []string{}}
+	v.Term = g.field(v.Term)
+	if s, ok := v.Value.(string); ok && v.Term == AllFields && len(g.opt.AllFields) > 0 {
+		if wildcard, ok := quotedWildcard(s); ok {
+			v.Value = wildcard
+		}
+	}
+	fragment, err := g.column(v)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"term": v.Term,
+			"sql":  query.Query,
+		}).Errorf("unknown column `%s`", v.Term)
+		return query, fmt.Errorf("invalid column: `%s` error: %s", v.Term, err)
+	}
+	if len(fragment.Fragments) > 0 {
+		prefix := v.Prefix
+		v.Prefix = ""
+		query, err = g.fanOut(fragment.Fragments, func(f Fragment) (Query, error) {
+			return g.existsTerm(v, f)
+		})
+		if err != nil {
+			return query, err
+		}
+		query.Query = prefixExpr(prefix, query.Query, g.opt)
+		return query, nil
+	}
+	return g.existsTerm(v, fragment)
+}
+
+// existsTerm renders the term query, within the correlated subquery of the fragment when set
+func (g *Generator) existsTerm(v lucenequery.TermQuery, fragment Fragment) (Query, error) {
+	if fragment.Exists == "" {
+		return g.term(v, fragment)
+	}
+	prefix := v.Prefix
+	v.Prefix = ""
+	query, err := g.term(v, fragment)
+	if err != nil {
+		return query, err
+	}
+	query.Query = prefixExpr(prefix, exists(fragment, query.Query), g.opt)
+	return query, nil
+}
+
+func (g *Generator) term(v lucenequery.TermQuery, fragment Fragment) (Query, error) {
+	var query, opt = Query{Query: "", Args: []interface{}{}, Columns: []string{}}, g.opt
+	fragment, err := castFragment(fragment, v.Cast)
+	if err != nil {
+		return query, err
+	}
+	if fragment.Column != "" {
+		query.Columns = append(query.Columns, fragment.Column)
+	}
+	if fragment.DistinctOn != nil {
+		g.distinctOn = appendColumns(g.distinctOn, fragment.DistinctOn...)
+	}
+	if fragment.Query != "" {
+		query.Query = prefixExpr(v.Prefix, fragment.Query, opt)
+		query.Args = append(query.Args, fragment.Args...)
+		return query, nil
+	}
+	if fragment.Enum != nil {
+		value, err := fragment.enumValue(v.Value)
+		if err != nil {
+			return query, err
+		}
+		v.Value = value
+	}
+	if fragment.UUID {
+		if err := fragment.validateUUID(v.Value); err != nil {
+			return query, err
+		}
+	}
+	term := fragment.Term
+	if term == "" {
+		if opt != nil && opt.DefaultField != "" {
+			term = opt.ColumnCase.Apply(opt.DefaultField)
+		} else {
+			return query, fmt.Errorf("invalid term value `%v` provided for term without a name", v.Value)
+		}
+	}
+	if v.Op == "contains" && fragment.Range != "" {
+		if fragment.Cast == "" {
+			fragment.Cast = rangeSubtypes[strings.ToLower(fragment.Range)]
+		}
+		query.Query = fmt.Sprintf("%s @> %s", term, fragment.placeholder())
 		query.Args = []interface{}{v.Value}
+		query.Query = prefixExpr(v.Prefix, query.Query, opt)
+		return query, nil
+	}
+	if v.Op == "contains" {
+		return query, fmt.Errorf("%w: `contains` for term `%s` which is not a range column", ErrUnsupportedOperator, v.Term)
+	}
+	op := "="
+	if v.Op != "" {
+		if mapped, ok := operatorMappings[v.Op]; ok {
+			op = mapped
+		} else if err := g.unknownOperator(v); err != nil {
+			return query, err
+		}
+	}
+	// values such as []byte and json.RawMessage are bound as is for the driver
+	query.Query = fmt.Sprintf("%s %s %s", term, op, fragment.placeholder())
+	query.Args = []interface{}{v.Value}
+
+	if v.Value == nil {
+		op = "IS"
+		query.Args = []interface{}{}
+		query.Query = fmt.Sprintf("%s %s NULL", term, op)
+		if v.Prefix == "-" {
+			query.Query = fmt.Sprintf("%s %s NOT NULL", term, op)
+		}
+		return query, nil
+	}
+
+	if t, ok := v.Value.(lucenequery.WildCardQuery); ok {
+		op = "LIKE"
+		q, err := g.Visitor.VisitWildcard(term, v, t)
+		if err != nil {
+			return query, err
+		}
+		query.Query, query.Args = q.Query, q.Args
+		if v.Prefix == "-" {
+			// negated patterns are matched with NOT LIKE rather than negating the predicate
+			negated := ""
+			if strings.HasPrefix(q.Query, term+" LIKE ") {
+				negated = term + " NOT LIKE " + strings.TrimPrefix(q.Query, term+" LIKE ")
+			} else if q.Query == term+" IS NOT NULL" {
+				negated = term + " IS NULL"
+			}
+			if negated != "" {
+				query.Query = fmt.Sprintf(" AND %s", negated)
+				if opt.SearchMode == SearchModeAny {
+					query.Query = fmt.Sprintf(" OR %s", negated)
+				}
+				return query, nil
+			}
+		}
+	}
+
+	if _, ok := v.Value.(float64); ok && opt.FloatEpsilon > 0 && (op == "=" || op == "<>") {
+		cmp := map[string]string{"=": "<", "<>": ">="}[op]
+		eps := strconv.FormatFloat(opt.FloatEpsilon, 'g', -1, 64)
+		query.Query = fmt.Sprintf("ABS(%s - %s) %s %s", term, fragment.placeholder(), cmp, eps)
+	}
+
+	if word, ok := v.Value.(string); ok && fragment.WholeWord && (op == "=" || op == "<>") {
+		op = map[string]string{"=": "~", "<>": "!~"}[op]
+		query.Query = fmt.Sprintf("%s %s %s", term, op, PlaceHolder)
+		query.Args = []interface{}{`\y` + regexp.QuoteMeta(word) + `\y`}
+	}
+
+	if text, ok := v.Value.(string); ok && fragment.WebSearch && (op == "=" || op == "<>") {
+		query.Query = fmt.Sprintf("to_tsvector(%s) @@ websearch_to_tsquery(%s)", term, PlaceHolder)
+		if op == "<>" {
+			query.Query = fmt.Sprintf("NOT %s", query.Query)
+		}
+		query.Args = []interface{}{text}
+	}
+
+	if _, ok := v.Value.(string); ok && fragment.Collation != "" && (op == "=" || op == "<>") {
+		collation := strings.Replace(fragment.Collation, `"`, `""`, -1)
+		query.Query = fmt.Sprintf(`%s %s %s COLLATE "%s"`, term, op, fragment.placeholder(), collation)
+	}
 
-		if v.Value == nil {
-			op = "IS"
+	if cmp, ok := jsonPathOperators[op]; ok && fragment.JSONPath != "" && isScalar(v.Value) {
+		query.Query = fmt.Sprintf("jsonb_path_exists(%s, %s::jsonpath, jsonb_build_object('v', %s))", term, PlaceHolder, fragment.placeholder())
+		if op == "<>" {
+			query.Query = fmt.Sprintf("NOT %s", query.Query)
+		}
+		query.Args = []interface{}{fmt.Sprintf("%s ? (@ %s $v)", fragment.JSONPath, cmp), v.Value}
+	}
+
+	if b, ok := v.Value.(bool); ok && opt.BooleanTests && (op == "=" || op == "<>") {
+		test := "IS"
+		if (op == "<>") != (v.Prefix == "-") {
+			test = "IS NOT"
+		}
+		query.Query = fmt.Sprintf("%s %s %s", term, test, strings.ToUpper(strconv.FormatBool(b)))
+		query.Args = []interface{}{}
+		if v.Prefix == "+" {
+			// the `-` prefix is the IS NOT test, a required term is still joined with AND
+			query.Query = prefixExpr(v.Prefix, query.Query, opt)
+		}
+		return query, nil
+	}
+
+	if b, ok := v.Value.(bool); ok && opt.InlineBooleans {
+		query.Query = fmt.Sprintf("%s %s %s", term, op, opt.Dialect.BooleanLiteral(b))
+		query.Args = []interface{}{}
+		g.inlined = append(g.inlined, [2]string{query.Query, fmt.Sprintf("%s %s %s", term, op, PlaceHolder)})
+	}
+
+	if values, ok := v.Value.([]interface{}); ok && op == "IN" && opt.InChunkSize > 0 && len(values) > opt.InChunkSize {
+		return g.inChunks(query, term, values, v.Prefix), nil
+	}
+	if op == "IN" {
+		query.Query = fmt.Sprintf("%s %s (%s)", term, op, PlaceHolder)
+		if opt.InHandler != nil {
+			query.Args[0] = opt.InHandler(v.Value)
+		}
+		if values, ok := inValues(query.Args[0]); ok && opt.ExpandInPlaceholders && len(values) > 0 {
+			query.Query = fmt.Sprintf("%s %s (%s)", term, op, strings.TrimSuffix(strings.Repeat(PlaceHolder+", ", len(values)), ", "))
+			query.Args = values
+		}
+		if t, ok := v.Value.([]interface{}); ok {
+			if len(t) == 0 {
+				query.Args = []interface{}{}
+				query.Query = "1 = 0"
+			}
+		}
+	}
+	if op == "??" {
+		query.Query = fmt.Sprintf("(%s = %s OR %s IS NULL)", term, fragment.placeholder(), term)
+	}
+	if (op == "@>" || op == "&&") && opt.Dialect == DialectOracle {
+		return query, fmt.Errorf("%w: `%s` array quantifier for term `%s` in the %s dialect", ErrUnsupportedOperator, v.Op, v.Term, opt.Dialect)
+	}
+	if op == "@>" || op == "&&" {
+		query.Query = fmt.Sprintf("%s %s %s", term, op, PlaceHolder)
+		if opt.InHandler != nil {
+			query.Args[0] = opt.InHandler(v.Value)
+		}
+		if opt.Dialect == DialectClickHouse {
+			query.Query = fmt.Sprintf("%s(%s, %s)", map[string]string{"@>": "hasAll", "&&": "hasAny"}[op], term, PlaceHolder)
+			if values, ok := inValues(query.Args[0]); ok && len(values) == 1 {
+				query.Query = fmt.Sprintf("has(%s, %s)", term, PlaceHolder)
+				query.Args[0] = values[0]
+			}
+		}
+		if t, ok := v.Value.([]interface{}); ok && len(t) == 0 {
+			// every array contains the empty array and none overlaps it
 			query.Args = []interface{}{}
-			query.Query = fmt.Sprintf("%s %s NULL", term, op)
-			if v.Prefix == "-" {
-				query.Query = fmt.Sprintf("%s %s NOT NULL", term, op)
+			query.Query = map[string]string{"@>": "1 = 1", "&&": "1 = 0"}[op]
+		}
+	}
+	if opt.Dialect == DialectClickHouse && strings.Contains(op, "~") && len(query.Args) == 1 {
+		if pattern, ok := query.Args[0].(string); ok {
+			// the re2 syntax of match has no case insensitive operator but the (?i) flag,
+			// and matches word boundaries with \b
+			query.Query = fmt.Sprintf("match(%s, %s)", term, PlaceHolder)
+			if strings.HasPrefix(op, "!") {
+				query.Query = fmt.Sprintf("NOT %s", query.Query)
 			}
-			return query, nil
+			if fragment.WholeWord {
+				pattern = `\b` + strings.TrimSuffix(strings.TrimPrefix(pattern, `\y`), `\y`) + `\b`
+			}
+			if strings.HasSuffix(op, "*") {
+				pattern = "(?i)" + pattern
+			}
+			query.Args = []interface{}{pattern}
+		}
+	}
+	if opt.Dialect == DialectOracle && strings.Contains(op, "~") {
+		if fragment.WholeWord {
+			return query, fmt.Errorf("%w: word boundaries for term `%s` in the %s dialect", ErrUnsupportedOperator, v.Term, opt.Dialect)
+		}
+		query.Query = fmt.Sprintf("REGEXP_LIKE(%s, %s)", term, PlaceHolder)
+		if strings.HasSuffix(op, "*") {
+			query.Query = fmt.Sprintf("REGEXP_LIKE(%s, %s, 'i')", term, PlaceHolder)
+		}
+		if strings.HasPrefix(op, "!") {
+			query.Query = fmt.Sprintf("NOT %s", query.Query)
+		}
+	}
+	query.Query = prefixExpr(v.Prefix, query.Query, opt)
+	return query, nil
+}
+
+// jsonPathOperators are the SQL/JSON path filter comparisons of the operators, a `<>` comparison
+// negates the match so no item selected by the path equals the value
+var jsonPathOperators = map[string]string{
+	"=":  "==",
+	"<>": "==",
+	">":  ">",
+	">=": ">=",
+	"<":  "<",
+	"<=": "<=",
+}
+
+// isScalar returns true for values compared as a single value, unlike lists and wildcard patterns
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case []interface{}, lucenequery.WildCardQuery:
+		return false
+	}
+	return true
+}
+
+// inValues returns the elements of an IN value bound with a placeholder each, the value can be any
+// slice such as the []string of an InHandler. Byte slices are bound as a single value
+func inValues(value interface{}) ([]interface{}, bool) {
+	if values, ok := value.([]interface{}); ok {
+		return values, true
+	}
+	v := reflect.ValueOf(value)
+	if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+	values := make([]interface{}, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values, true
+}
+
+// inChunks renders the IN expression of the values split in chunks of InChunkSize values. The
+// negation of a negated list is applied to every chunk, keeping the joiner of the prefix
+func (g *Generator) inChunks(query Query, term string, values []interface{}, prefix string) Query {
+	op, join := "IN", strings.ToUpper(g.opt.InChunkJoin)
+	if join == "" {
+		join = "OR"
+	}
+	if prefix == "-" {
+		op, join = "NOT IN", map[string]string{"OR": "AND", "AND": "OR"}[join]
+	}
+	var exprs []string
+	query.Args = []interface{}{}
+	for start := 0; start < len(values); start += g.opt.InChunkSize {
+		end := start + g.opt.InChunkSize
+		if end > len(values) {
+			end = len(values)
+		}
+		var arg interface{} = values[start:end]
+		if g.opt.InHandler != nil {
+			arg = g.opt.InHandler(arg)
 		}
+		if chunk, ok := inValues(arg); ok && g.opt.ExpandInPlaceholders && len(chunk) > 0 {
+			exprs = append(exprs, fmt.Sprintf("%s %s (%s)", term, op, strings.TrimSuffix(strings.Repeat(PlaceHolder+", ", len(chunk)), ", ")))
+			query.Args = append(query.Args, chunk...)
+			continue
+		}
+		exprs = append(exprs, fmt.Sprintf("%s %s (%s)", term, op, PlaceHolder))
+		query.Args = append(query.Args, arg)
+	}
+	query.Query = fmt.Sprintf("(%s)", strings.Join(exprs, " "+join+" "))
+	if prefix == "-" {
+		joiner := "AND"
+		if g.opt.SearchMode == SearchModeAny {
+			joiner = "OR"
+		}
+		query.Query = fmt.Sprintf(" %s %s", joiner, query.Query)
+		return query
+	}
+	query.Query = prefixExpr(prefix, query.Query, g.opt)
+	return query
+}
 
-		if t, ok := v.Value.(lucenequery.WildCardQuery); ok {
-			op = "LIKE"
-			switch t.Kind() {
-			case "prefix":
-				query.Query = fmt.Sprintf("%s %s '%s%%'", term, op, PlaceHolder)
+// VisitWildcard renders the wildcard value of a term query against the column
+func (g *Generator) VisitWildcard(term string, v lucenequery.TermQuery, t lucenequery.WildCardQuery) (Query, error) {
+	var query, op, wc = Query{Query: "", Args: []interface{}{}, Columns: []string{}}, "LIKE", g.wildcards()
+	switch t.Kind() {
+	case "prefix":
+		if g.opt.PrefixAsRange {
+			if upper, ok := prefixUpperBound(t.Prefix); ok {
+				query.Query = fmt.Sprintf("(%s >= %s AND %s < %s)", term, PlaceHolder, term, PlaceHolder)
+				query.Args = []interface{}{t.Prefix, upper}
+			} else {
+				query.Query = fmt.Sprintf("%s >= %s", term, PlaceHolder)
 				query.Args = []interface{}{t.Prefix}
-			case "suffix":
-				query.Query = fmt.Sprintf("%s %s '%%%s'", term, op, PlaceHolder)
-				query.Args = []interface{}{t.Suffix}
-			case "between":
-				query.Query = fmt.Sprintf("%s %s '%s%%%s'", term, op, PlaceHolder, PlaceHolder)
-				query.Args = []interface{}{t.Prefix, t.Suffix}
-			case "any":
-				query.Query = fmt.Sprintf("%s %s '%%%s%%'", term, op, PlaceHolder)
-				query.Args = []interface{}{t.Term}
-			default:
-				query.Query = fmt.Sprintf("%s IS NOT NULL", term)
-				query.Args = []interface{}{}
 			}
+			return query, nil
 		}
+		query.Query = fmt.Sprintf("%s %s '%s%s'", term, op, PlaceHolder, wc.Any)
+		query.Args = []interface{}{g.escapeLike(t.Prefix)}
+	case "suffix":
+		query.Query = fmt.Sprintf("%s %s '%s%s'", term, op, wc.Any, PlaceHolder)
+		query.Args = []interface{}{g.escapeLike(t.Suffix)}
+	case "between":
+		query.Query = fmt.Sprintf("%s %s '%s%s%s'", term, op, PlaceHolder, wc.Any, PlaceHolder)
+		query.Args = []interface{}{g.escapeLike(t.Prefix), g.escapeLike(t.Suffix)}
+	case "any":
+		query.Query = fmt.Sprintf("%s %s '%s%s%s'", term, op, wc.Any, PlaceHolder, wc.Any)
+		query.Args = []interface{}{g.escapeLike(t.Term)}
+	default:
+		query.Query = fmt.Sprintf("%s IS NOT NULL", term)
+		query.Args = []interface{}{}
+		return query, nil
+	}
+	if esc := g.opt.WildcardChars.Escape; esc != "" {
+		query.Query += fmt.Sprintf(" ESCAPE '%s'", strings.ReplaceAll(esc, "'", "''"))
+	}
+	return query, nil
+}
+
+// VisitRange renders a range query
+func (g *Generator) VisitRange(v lucenequery.RangeQuery) (Query, error) {
+	query := Query{Query: "", Args: []interface{}{}, Columns: []string{}}
+	if _, err := v.Kind(); err != nil {
+		return query, fmt.Errorf("invalid column: `%s` error: %s", v.Term, err)
+	}
+	v.Term = g.field(v.Term)
+	fragment, err := g.column(v)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"term": v.Term,
+			"sql":  fragment,
+		}).Errorf("unknown column `%s`", v.Term)
+		return query, fmt.Errorf("invalid column: `%s` error: %s", v.Term, err)
+	}
+	if len(fragment.Fragments) > 0 {
+		return g.fanOut(fragment.Fragments, func(f Fragment) (Query, error) {
+			return g.existsRange(v, f)
+		})
+	}
+	return g.existsRange(v, fragment)
+}
 
-		if op == "IN" {
-			query.Query = fmt.Sprintf("%s %s (%s)", term, op, PlaceHolder)
-			if opt.InHandler != nil {
-				query.Args[0] = opt.InHandler(v.Value)
+// existsRange renders the range query, within the correlated subquery of the fragment when set
+func (g *Generator) existsRange(v lucenequery.RangeQuery, fragment Fragment) (Query, error) {
+	query, err := g.rangeQuery(v, fragment)
+	if err == nil && fragment.Exists != "" {
+		query.Query = exists(fragment, query.Query)
+	}
+	return query, err
+}
+
+func (g *Generator) rangeQuery(v lucenequery.RangeQuery, fragment Fragment) (Query, error) {
+	var query, opt = Query{Query: "", Args: []interface{}{}, Columns: []string{}}, g.opt
+	fragment, err := castFragment(fragment, v.Cast)
+	if err != nil {
+		return query, err
+	}
+	op, _ := v.Kind()
+	if fragment.Column != "" {
+		query.Columns = append(query.Columns, fragment.Column)
+	}
+	if fragment.DistinctOn != nil {
+		g.distinctOn = appendColumns(g.distinctOn, fragment.DistinctOn...)
+	}
+	if fragment.Query != "" {
+		query.Query = fragment.Query
+		query.Args = append(query.Args, fragment.Args...)
+		return query, nil
+	}
+	if fragment.Enum != nil {
+		for _, bound := range []*interface{}{&v.Min, &v.Max} {
+			if fmt.Sprintf("%v", *bound) == "*" {
+				continue
 			}
-			if t, ok := v.Value.([]interface{}); ok {
-				if len(t) == 0 {
-					query.Args = []interface{}{}
-					query.Query = "1 = 0"
-				}
+			value, err := fragment.enumValue(*bound)
+			if err != nil {
+				return query, err
 			}
+			*bound = value
 		}
-		if v.Prefix == "+" {
-			query.Query = fmt.Sprintf(" AND %s", query.Query)
-		} else if v.Prefix == "-" {
-			if opt.SearchMode == SearchModeAny {
-				query.Query = fmt.Sprintf(" OR NOT %s", query.Query)
-			} else {
-				query.Query = fmt.Sprintf(" AND NOT %s", query.Query)
+	}
+	if fragment.UUID {
+		for _, bound := range []interface{}{v.Min, v.Max} {
+			if fmt.Sprintf("%v", bound) == "*" {
+				continue
+			}
+			if err := fragment.validateUUID(bound); err != nil {
+				return query, err
 			}
 		}
+	}
+	term := fragment.Term
+	if term == "" {
+		if opt != nil && opt.DefaultField != "" {
+			term = opt.ColumnCase.Apply(opt.DefaultField)
+		} else {
+			return query, fmt.Errorf("invalid range term value `%v` provided for term without a name", v)
+		}
+	}
+	if fragment.JSONPath != "" {
+		return jsonPathRange(query, term, v, fragment)
+	}
+	placeholder := fragment.placeholder()
+	switch op {
+	case "gt", "gte":
+		query.Query = fmt.Sprintf("%s %s %s", term, operatorMappings[op], placeholder)
+		query.Args = []interface{}{v.Min}
 		return query, nil
+	case "lt", "lte":
+		query.Query = fmt.Sprintf("%s %s %s", term, operatorMappings[op], placeholder)
+		query.Args = []interface{}{v.Max}
+		return query, nil
+	case "between":
+		if v.Inclusive {
+			query.Query = fmt.Sprintf("%s %s %s and %s", term, operatorMappings[op], placeholder, placeholder)
+			query.Args = []interface{}{v.Min, v.Max}
+			return query, nil
+		}
+		query.Query = fmt.Sprintf("%s > %s and %s < %s", term, placeholder, term, placeholder)
+		query.Args = []interface{}{v.Min, v.Max}
+		return query, nil
+	default:
+		return query, fmt.Errorf("unknown range type: %s", op)
+	}
+}
+
+// jsonPathRange renders the range as the SQL/JSON path filter of the fragment, matching when any of
+// the items selected by the path is within the bounds e.g.
+// `jsonb_path_exists(data, ?::jsonpath, jsonb_build_object('min', ?, 'max', ?))` bound with `$.items[*].price ? (@ >= $min && @ <= $max)`
+func jsonPathRange(query Query, term string, v lucenequery.RangeQuery, fragment Fragment) (Query, error) {
+	op, _ := v.Kind()
+	var filters, bounds []string
+	lower, upper := func(cmp string) {
+		filters = append(filters, fmt.Sprintf("@ %s $min", cmp))
+		bounds = append(bounds, fmt.Sprintf("'min', %s", fragment.placeholder()))
+		query.Args = append(query.Args, v.Min)
+	}, func(cmp string) {
+		filters = append(filters, fmt.Sprintf("@ %s $max", cmp))
+		bounds = append(bounds, fmt.Sprintf("'max', %s", fragment.placeholder()))
+		query.Args = append(query.Args, v.Max)
+	}
+	switch op {
+	case "gt", "gte", "lt", "lte":
+		if op[0] == 'g' {
+			lower(operatorMappings[op])
+		} else {
+			upper(operatorMappings[op])
+		}
+	case "between":
+		if v.Inclusive {
+			lower(">=")
+			upper("<=")
+		} else {
+			lower(">")
+			upper("<")
+		}
+	default:
+		return query, fmt.Errorf("unknown range type: %s", op)
+	}
+	path := fmt.Sprintf("%s ? (%s)", fragment.JSONPath, strings.Join(filters, " && "))
+	query.Query = fmt.Sprintf("jsonb_path_exists(%s, %s::jsonpath, jsonb_build_object(%s))", term, PlaceHolder, strings.Join(bounds, ", "))
+	query.Args = append([]interface{}{path}, query.Args...)
+	return query, nil
+}
+
+// wildcards returns the LIKE wildcards of the options, defaulting to `%` and `_`
+func (g *Generator) wildcards() WildcardChars {
+	wc := g.opt.WildcardChars
+	if wc.Any == "" {
+		wc.Any = "%"
+	}
+	if wc.Single == "" {
+		wc.Single = "_"
+	}
+	return wc
+}
+
+// escapeLike escapes the custom wildcard characters in a LIKE pattern value with the escape
+// character, a backslash by default
+func (g *Generator) escapeLike(value string) string {
+	wc := g.opt.WildcardChars
+	if wc.Any == "" && wc.Single == "" && wc.Escape == "" {
+		return value
+	}
+	wc = g.wildcards()
+	esc := wc.Escape
+	if esc == "" {
+		esc = `\`
+	}
+	return strings.NewReplacer(esc, esc+esc, wc.Any, esc+wc.Any, wc.Single, esc+wc.Single).Replace(value)
+}
+
+// prefixUpperBound returns the smallest string greater than every string starting with the prefix,
+// there is no upper bound when the prefix only consists of the maximum rune
+func prefixUpperBound(prefix string) (string, bool) {
+	runes := []rune(prefix)
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == utf8.MaxRune {
+			continue
+		}
+		r := runes[i] + 1
+		if r >= 0xD800 && r <= 0xDFFF {
+			// skip the surrogate halves which are not valid runes
+			r = 0xE000
+		}
+		return string(append(runes[:i], r)), true
+	}
+	return "", false
+}
+
+// groupBy removes the group by pseudo-fields from the filter returning the columns they reference
+func (g *Generator) groupBy(filter interface{}) (interface{}, []string, error) {
+	switch v := filter.(type) {
+	case []interface{}:
+		var nodes []interface{}
+		var columns []string
+		for _, n := range v {
+			node, c, err := g.groupBy(n)
+			if err != nil {
+				return filter, columns, err
+			}
+			if node != nil {
+				nodes = append(nodes, node)
+			}
+			columns = append(columns, c...)
+		}
+		return nodes, columns, nil
+	case lucenequery.BooleanExpression:
+		args, columns, err := g.groupBy(v.Args)
+		if err != nil || len(args.([]interface{})) == 0 {
+			return nil, columns, err
+		}
+		if len(columns) > 0 && len(args.([]interface{})) == 1 && !(v.Op == "NOT" && len(v.Args) == 1) {
+			return args.([]interface{})[0], columns, nil
+		}
+		v.Args = args.([]interface{})
+		return v, columns, nil
+	case lucenequery.TermQuery:
+		if v.Term != "group" && v.Term != "groupby" {
+			return v, nil, nil
+		}
+		values, ok := v.Value.([]interface{})
+		if !ok {
+			values = []interface{}{v.Value}
+		}
+		var columns []string
+		for _, value := range values {
+			name, ok := value.(string)
+			if !ok || name == "" {
+				return nil, columns, fmt.Errorf("invalid group by value `%v`", value)
+			}
+			fragment, err := g.opt.ColumnHandler(lucenequery.TermQuery{Term: g.field(name)})
+			if err != nil {
+				return nil, columns, fmt.Errorf("invalid group by column: `%s` error: %s", name, err)
+			}
+			columns = append(columns, fragment.Term)
+		}
+		return nil, columns, nil
+	}
+	return filter, nil, nil
+}
+
+// excludeNegations returns the OR expression with its negated arguments moved out of the OR
+// and joined with AND, false is returned when none of the arguments is negated
+func excludeNegations(v lucenequery.BooleanExpression) (interface{}, bool) {
+	var positives, negatives []interface{}
+	for _, arg := range sequence(v) {
+		switch a := arg.(type) {
+		case lucenequery.TermQuery:
+			if a.Prefix == "-" {
+				negatives = append(negatives, a)
+				continue
+			}
+		case lucenequery.BooleanExpression:
+			if a.Op == "NOT" && len(a.Args) == 1 {
+				negatives = append(negatives, a)
+				continue
+			}
+		}
+		positives = append(positives, arg)
+	}
+	if len(negatives) == 0 {
+		return v, false
+	}
+	if len(positives) > 1 {
+		positives = []interface{}{lucenequery.BooleanExpression{Op: v.Op, Args: positives}}
+	}
+	return lucenequery.BooleanExpression{Op: "AND", Args: append(positives, negatives...)}, true
+}
+
+// sequence returns the arguments of the expression with the arguments of the nested expressions
+// of the same operator, the parser nests a sequence of terms to the right: a b c => a (b c)
+func sequence(v lucenequery.BooleanExpression) []interface{} {
+	var args []interface{}
+	for _, arg := range v.Args {
+		if b, ok := arg.(lucenequery.BooleanExpression); ok && b.Op == v.Op && v.Op != "NOT" {
+			args = append(args, sequence(b)...)
+			continue
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
+// skipEmptyStrings returns the filter without the terms matching an empty string,
+// false is returned when nothing is left of the filter
+func skipEmptyStrings(filter interface{}) (interface{}, bool) {
+	switch v := filter.(type) {
+	case lucenequery.TermQuery:
+		return v, !(v.Value == "" && v.Prefix == "")
+	case []interface{}:
+		var nodes []interface{}
+		for _, node := range v {
+			if node, ok := skipEmptyStrings(node); ok {
+				nodes = append(nodes, node)
+			}
+		}
+		return nodes, len(nodes) > 0
+	case lucenequery.BooleanExpression:
+		var nodes []interface{}
+		first := false
+		for i, arg := range v.Args {
+			if node, ok := skipEmptyStrings(arg); ok {
+				nodes = append(nodes, node)
+				first = first || i == 0
+			}
+		}
+		if len(nodes) == 0 {
+			return nil, false
+		}
+		// `a NOT b` keeps the negation of b when a is removed
+		if len(nodes) == 1 && (v.Op != "NOT" || first && len(v.Args) > 1) {
+			return nodes[0], true
+		}
+		v.Args = nodes
+		return v, true
+	}
+	return filter, true
+}
+
+// onColumn reports the field of a term or range to the OnColumn callback
+func (g *Generator) onColumn(field, op string) {
+	if g.opt.OnColumn == nil {
+		return
+	}
+	if field == "" {
+		field = g.opt.DefaultField
+	}
+	g.opt.OnColumn(field, op)
+}
+
+// bind passes the args of a rendered term or range to the BindHook
+func (g *Generator) bind(op string, query Query, err error) (Query, error) {
+	if err == nil && g.opt.TrimValues && (op != "LIKE" || g.opt.TrimPatterns) {
+		for i, arg := range query.Args {
+			query.Args[i] = trimValue(arg)
+		}
+	}
+	if err != nil || g.opt.BindHook == nil {
+		g.args += len(query.Args)
+		return query, err
+	}
+	for i, arg := range query.Args {
+		value, err := g.opt.BindHook(g.args, op, arg)
+		if err != nil {
+			return query, fmt.Errorf("failed to bind `%v`: %w", arg, err)
+		}
+		query.Args[i] = value
+		g.args++
+	}
+	return query, nil
+}
+
+// resolveTime returns the time of a relative time keyword value, other values are returned as is
+func (g *Generator) resolveTime(value interface{}) interface{} {
+	switch v := value.(type) {
+	case lucenequery.TimeAnchor:
+		now := time.Now
+		if g.opt.Now != nil {
+			now = g.opt.Now
+		}
+		return g.resolveTime(v.Time(now()))
+	case time.Time:
+		if g.opt.Location != nil {
+			return v.In(g.opt.Location)
+		}
+	case []interface{}:
+		if g.opt.Location == nil {
+			return value
+		}
+		values := make([]interface{}, len(v))
+		for i, item := range v {
+			values[i] = g.resolveTime(item)
+		}
+		return values
+	}
+	return value
+}
+
+// mapFilter returns the query matching every field of the filter with its value, joined with AND
+// in the order of the fields. Lists match any of their values and nil matches null fields
+func mapFilter(filter map[string]interface{}) interface{} {
+	fields := make([]string, 0, len(filter))
+	for field := range filter {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	builder := lucenequery.New()
+	for i, field := range fields {
+		if i > 0 {
+			builder.And()
+		}
+		value := reflect.ValueOf(filter[field])
+		if value.Kind() != reflect.Slice || value.Type().Elem().Kind() == reflect.Uint8 {
+			builder.Term(field, filter[field])
+			continue
+		}
+		values := make([]interface{}, value.Len())
+		for j := range values {
+			values[j] = value.Index(j).Interface()
+		}
+		builder.In(field, values...)
+	}
+	return builder.Build()
+}
+
+// trimValue strips the whitespace of a string value or of the strings in a list of values
+func trimValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, item := range v {
+			values[i] = trimValue(item)
+		}
+		return values
+	}
+	return value
+}
+
+// termOperator returns the SQL operator the value of the term query is compared with
+func termOperator(v lucenequery.TermQuery) string {
+	if v.Value == nil {
+		return "IS"
+	}
+	if _, ok := v.Value.(lucenequery.WildCardQuery); ok {
+		return "LIKE"
+	}
+	if op, ok := operatorMappings[v.Op]; ok {
+		return op
+	}
+	return "="
+}
+
+// unknownOperator applies the OnUnknownOperator policy to a term with an unmapped operator
+func (g *Generator) unknownOperator(v lucenequery.TermQuery) error {
+	switch g.opt.OnUnknownOperator {
+	case OperatorPolicyError:
+		return fmt.Errorf("%w: `%s` for term `%s`", ErrUnknownOperator, v.Op, v.Term)
+	case OperatorPolicyWarn:
+		log.WithFields(log.Fields{
+			"term": v.Term,
+			"op":   v.Op,
+		}).Warnf("unknown operator `%s`, comparing with `=`", v.Op)
+	}
+	return nil
+}
+
+// column returns the column fragment of the node, the `@all` pseudo-field is fanned
+// out across the fragments of ToSQLOptions.AllFields
+func (g *Generator) column(node interface{}) (Fragment, error) {
+	var term string
+	switch v := node.(type) {
+	case lucenequery.TermQuery:
+		term = v.Term
 	case lucenequery.RangeQuery:
-		op, err := v.Kind()
+		term = v.Term
+	}
+	if term != AllFields || len(g.opt.AllFields) == 0 {
+		return g.resolve(node)
+	}
+	var fragments []Fragment
+	for _, name := range g.opt.AllFields {
+		var field interface{}
+		switch v := node.(type) {
+		case lucenequery.TermQuery:
+			v.Term = g.field(name)
+			field = v
+		case lucenequery.RangeQuery:
+			v.Term = g.field(name)
+			field = v
+		}
+		fragment, err := g.resolve(field)
 		if err != nil {
-			return query, fmt.Errorf("invalid column: `%s` error: %s", v.Term, err)
+			return fragment, err
+		}
+		if len(fragment.Fragments) > 0 {
+			fragments = append(fragments, fragment.Fragments...)
+			continue
+		}
+		fragments = append(fragments, fragment)
+	}
+	return Fragment{Fragments: fragments}, nil
+}
+
+// quotedWildcard returns the wildcard of a quoted search box value starting or ending with `*`
+// e.g. `@all: "foo*"` matches the fields starting with foo as `@all: foo*` does
+func quotedWildcard(value string) (lucenequery.WildCardQuery, bool) {
+	prefix, suffix := strings.HasSuffix(value, "*"), strings.HasPrefix(value, "*")
+	term := strings.TrimSuffix(strings.TrimPrefix(value, "*"), "*")
+	switch {
+	case term == "" || strings.Contains(term, "*"):
+		return lucenequery.WildCardQuery{}, false
+	case prefix && suffix:
+		return lucenequery.WildCardQuery{Term: term}, true
+	case prefix:
+		return lucenequery.WildCardQuery{Prefix: term}, true
+	case suffix:
+		return lucenequery.WildCardQuery{Suffix: term}, true
+	}
+	return lucenequery.WildCardQuery{}, false
+}
+
+// resolve returns the fragment of the ColumnHandler for the node, with the dotted
+// fields of ToSQLOptions.JSONColumns rewritten to JSON path expressions
+func (g *Generator) resolve(node interface{}) (Fragment, error) {
+	fragment, err := g.opt.ColumnHandler(node)
+	if err != nil {
+		return fragment, err
+	}
+	if len(g.opt.JSONColumns) > 0 {
+		numeric := false
+		if r, ok := node.(lucenequery.RangeQuery); ok {
+			numeric = isNumber(r.Min) && isNumber(r.Max)
 		}
-		fragment, err := opt.ColumnHandler(v)
+		fragment.Term = g.jsonPath(fragment.Term, numeric)
+		for i, f := range fragment.Fragments {
+			fragment.Fragments[i].Term = g.jsonPath(f.Term, numeric)
+		}
+	}
+	return g.columnCase(fragment), nil
+}
+
+// columnCase returns the fragment with its column identifiers in the ToSQLOptions.ColumnCase
+func (g *Generator) columnCase(fragment Fragment) Fragment {
+	if g.opt.ColumnCase == ColumnCaseDefault {
+		return fragment
+	}
+	fragment.Term = g.opt.ColumnCase.Apply(fragment.Term)
+	fragment.Column = g.opt.ColumnCase.Apply(fragment.Column)
+	if fragment.DistinctOn != nil {
+		distinctOn := make([]string, len(fragment.DistinctOn))
+		for i, column := range fragment.DistinctOn {
+			distinctOn[i] = g.opt.ColumnCase.Apply(column)
+		}
+		fragment.DistinctOn = distinctOn
+	}
+	if fragment.Fragments != nil {
+		fragments := make([]Fragment, len(fragment.Fragments))
+		for i, f := range fragment.Fragments {
+			fragments[i] = g.columnCase(f)
+		}
+		fragment.Fragments = fragments
+	}
+	return fragment
+}
+
+// jsonPath returns the JSON path expression of a dotted term whose first segment is one of the
+// ToSQLOptions.JSONColumns e.g. `labels.env` is rendered as `labels->>'env'`. Numeric paths are
+// cast so they are compared as numbers
+func (g *Generator) jsonPath(term string, numeric bool) string {
+	parts := strings.Split(term, ".")
+	if len(parts) < 2 {
+		return term
+	}
+	for _, column := range g.opt.JSONColumns {
+		if column != parts[0] {
+			continue
+		}
+		var sb strings.Builder
+		sb.WriteString(parts[0])
+		for i, key := range parts[1:] {
+			op := "->"
+			if i == len(parts)-2 {
+				op = "->>"
+			}
+			sb.WriteString(fmt.Sprintf("%s'%s'", op, strings.Replace(key, "'", "''", -1)))
+		}
+		if numeric {
+			return fmt.Sprintf("CAST(%s AS numeric)", sb.String())
+		}
+		return sb.String()
+	}
+	return term
+}
+
+// isNumber returns true for numeric and unbounded (`*`) range values
+func isNumber(v interface{}) bool {
+	switch v.(type) {
+	case int, int32, int64, float32, float64:
+		return true
+	}
+	return v == "*"
+}
+
+// field resolves the alias of the query field
+func (g *Generator) field(name string) string {
+	if alias, ok := g.opt.FieldAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// fanOut renders the node against each of the column fragments,
+// the generated expressions are joined with OR
+func (g *Generator) fanOut(fragments []Fragment, render func(Fragment) (Query, error)) (Query, error) {
+	var query, exprs = Query{Query: "", Args: []interface{}{}, Columns: []string{}}, []string{}
+	for _, fragment := range fragments {
+		q, err := render(fragment)
 		if err != nil {
-			log.WithFields(log.Fields{
-				"term": v.Term,
-				"sql":  fragment,
-			}).Errorf("unknown column `%s`", v.Term)
-			return query, fmt.Errorf("invalid column: `%s` error: %s", v.Term, err)
-		}
-		if fragment.Column != "" {
-			query.Columns = append(query.Columns, fragment.Column)
-		}
-		if fragment.Query != "" {
-			query.Query = fragment.Query
-			query.Args = fragment.Args
-			return query, nil
+			return query, err
 		}
-		term := fragment.Term
-		if term == "" {
-			if opt != nil && opt.DefaultField != "" {
-				term = opt.DefaultField
-			} else {
-				return query, fmt.Errorf("invalid range term value `%v` provided for term without a name", v)
+		exprs = append(exprs, strings.TrimSpace(q.Query))
+		query.Columns = appendColumns(query.Columns, q.Columns...)
+		query.Args = append(query.Args, q.Args...)
+	}
+	query.Query = fmt.Sprintf("(%s)", strings.Join(exprs, " OR "))
+	return query, nil
+}
+
+// exists returns the predicate added to the conditions of the correlated subquery of the fragment
+func exists(fragment Fragment, predicate string) string {
+	return fmt.Sprintf("EXISTS (%s AND %s)", fragment.Exists, strings.TrimSpace(predicate))
+}
+
+func cleanExpr(expr string) string {
+	for _, r := range regexes {
+		expr = r.Pattern.ReplaceAllString(expr, r.Replace)
+	}
+	return strings.TrimSpace(expr)
+}
+
+// wrap applies the wrapping policy to the parentheses enclosing the expression
+func wrap(expr string, policy WrapResult) string {
+	switch policy {
+	case WrapResultNever:
+		return unwrap(expr)
+	case WrapResultAlways:
+		if expr == "" || enclosed(expr) {
+			return expr
+		}
+		return fmt.Sprintf("(%s)", expr)
+	case WrapResultWhenMultiple:
+		expr = unwrap(expr)
+		if multiple(expr) {
+			return fmt.Sprintf("(%s)", expr)
+		}
+		return expr
+	}
+	return expr
+}
+
+// unwrap removes the parentheses enclosing the whole expression
+func unwrap(expr string) string {
+	for enclosed(expr) {
+		expr = strings.TrimSpace(expr[1 : len(expr)-1])
+	}
+	return expr
+}
+
+// enclosed returns true when the whole expression is enclosed in a pair of parentheses
+func enclosed(expr string) bool {
+	if !strings.HasPrefix(expr, "(") || !strings.HasSuffix(expr, ")") {
+		return false
+	}
+	depth, quoted := 0, false
+	for i, r := range expr {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth == 0 {
+				return i == len(expr)-1
 			}
 		}
-		switch op {
-		case "gt", "gte":
-			query.Query = fmt.Sprintf("%s %s %s", term, operatorMappings[op], PlaceHolder)
-			query.Args = []interface{}{v.Min}
-			return query, nil
-		case "lt", "lte":
-			query.Query = fmt.Sprintf("%s %s %s", term, operatorMappings[op], PlaceHolder)
-			query.Args = []interface{}{v.Max}
-			return query, nil
-		case "between":
-			if v.Inclusive {
-				query.Query = fmt.Sprintf("%s %s %s and %s", term, operatorMappings[op], PlaceHolder, PlaceHolder)
-				query.Args = []interface{}{v.Min, v.Max}
-				return query, nil
+	}
+	return false
+}
+
+// multiple returns true when the expression joins predicates with AND/OR outside of parentheses
+func multiple(expr string) bool {
+	depth, quoted := 0, false
+	for i, r := range expr {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case depth == 0 && (strings.HasPrefix(expr[i:], " AND ") || strings.HasPrefix(expr[i:], " OR ")):
+			return true
+		}
+	}
+	return false
+}
+
+// clauses splits the expression on the AND/OR operators outside of parentheses,
+// the AND of a BETWEEN predicate is kept in the clause
+func clauses(expr string) (parts []string, ops []string) {
+	depth, quoted, between, start := 0, false, false, 0
+	for i, r := range expr {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case depth == 0 && hasPrefixFold(expr[i:], " BETWEEN "):
+			between = true
+		case depth == 0 && between && hasPrefixFold(expr[i:], " AND "):
+			between = false
+		case depth == 0 && i >= start && (strings.HasPrefix(expr[i:], " AND ") || strings.HasPrefix(expr[i:], " OR ")):
+			op := strings.TrimSpace(expr[i : i+4])
+			parts, ops = append(parts, expr[start:i]), append(ops, op)
+			start = i + len(op) + 2
+		}
+	}
+	return append(parts, expr[start:]), ops
+}
+
+// hasPrefixFold reports whether s begins with prefix ignoring case
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// pretty formats the boolean structure of the expression across indented lines
+func pretty(expr, indent string) string {
+	const tab = "  "
+	if enclosed(expr) {
+		inner := expr[1 : len(expr)-1]
+		if parts, _ := clauses(inner); len(parts) > 1 {
+			return "(\n" + indent + tab + pretty(inner, indent+tab) + "\n" + indent + ")"
+		}
+		return "(" + pretty(inner, indent) + ")"
+	}
+	parts, ops := clauses(expr)
+	if len(parts) == 1 {
+		if strings.HasPrefix(expr, "NOT ") {
+			return "NOT " + pretty(expr[4:], indent)
+		}
+		return expr
+	}
+	var sb strings.Builder
+	for i, part := range parts {
+		if i > 0 {
+			sb.WriteString("\n" + indent + ops[i-1] + " ")
+		}
+		sb.WriteString(pretty(part, indent))
+	}
+	return sb.String()
+}
+
+// appendColumns appends the columns not yet in the list, keeping the order they are first seen
+func appendColumns(columns []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, c := range columns {
+			if c == value {
+				found = true
+				break
 			}
-			query.Query = fmt.Sprintf("%s > %s and %s < %s", term, PlaceHolder, term, PlaceHolder)
-			query.Args = []interface{}{v.Min, v.Max}
-			return query, nil
-		default:
-			return query, fmt.Errorf("unknown range type: %s", op)
 		}
-	default:
-		return query, fmt.Errorf("unknown type: `%T`", v)
+		if !found {
+			columns = append(columns, value)
+		}
 	}
-}
\ No newline at end of file
+	return columns
+}
+
+// prefixExpr applies the +/- prefix operator of a term to the expression
+func prefixExpr(prefix, expr string, opt *ToSQLOptions) string {
+	if prefix == "+" {
+		return fmt.Sprintf(" AND %s", expr)
+	} else if prefix == "-" {
+		if opt.SearchMode == SearchModeAny {
+			return fmt.Sprintf(" OR NOT %s", expr)
+		}
+		return fmt.Sprintf(" AND NOT %s", expr)
+	}
+	return expr
+}
@@ -1,8 +1,15 @@
 package sql
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stevejuma/pkg/lucenequery"
 	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestGenerateSQL(t *testing.T) {
@@ -65,6 +72,26 @@ func TestGenerateSQL(t *testing.T) {
 			sql:    `(age IS NULL OR available = ?)`,
 			args:   []interface{}{true},
 		},
+		{
+			filter: `active: eq true`,
+			sql:    `active = ?`,
+			args:   []interface{}{true},
+		},
+		{
+			filter: `active: neq false`,
+			sql:    `active <> ?`,
+			args:   []interface{}{false},
+		},
+		{
+			filter: `active: != true disabled: eq false`,
+			sql:    `(active <> ? OR disabled = ?)`,
+			args:   []interface{}{true, false},
+		},
+		{
+			filter: `code: TRUE`,
+			sql:    `code = ?`,
+			args:   []interface{}{"TRUE"},
+		},
 		{
 			filter: `value: *`,
 			sql:    `value IS NOT NULL`,
@@ -133,3 +160,2178 @@ func TestGenerateSQL(t *testing.T) {
 		assert.Equal(t, dt.args, query.Args, dt)
 	}
 }
+
+func TestNegation(t *testing.T) {
+	cases := []struct {
+		filters []string
+		mode    SearchMode
+		sql     string
+		args    []interface{}
+	}{
+		{
+			filters: []string{`NOT status:closed`, `-status:closed`, `status: -closed`},
+			sql:     `NOT status = ?`,
+			args:    []interface{}{"closed"},
+		},
+		{
+			filters: []string{`name: peter AND NOT status:closed`, `name: peter AND -status:closed`, `name: peter AND status: -closed`},
+			mode:    SearchModeAll,
+			sql:     `(name = ? AND NOT status = ?)`,
+			args:    []interface{}{"peter", "closed"},
+		},
+		{
+			filters: []string{`NOT status:closed AND name: peter`, `-status:closed AND name: peter`},
+			mode:    SearchModeAll,
+			sql:     `(NOT status = ? AND name = ?)`,
+			args:    []interface{}{"closed", "peter"},
+		},
+		{
+			filters: []string{`NOT (status:closed OR status:draft)`, `-status:(closed OR draft)`},
+			sql:     `NOT (status = ? OR status = ?)`,
+			args:    []interface{}{"closed", "draft"},
+		},
+		{
+			filters: []string{`name: peter AND NOT (status:closed OR status:draft)`},
+			mode:    SearchModeAll,
+			sql:     `(name = ? AND NOT (status = ? OR status = ?))`,
+			args:    []interface{}{"peter", "closed", "draft"},
+		},
+		{
+			filters: []string{`NOT age:[1 TO 5]`, `-age:[1 TO 5]`},
+			sql:     `NOT age BETWEEN ? and ?`,
+			args:    []interface{}{1, 5},
+		},
+	}
+	for _, dt := range cases {
+		for _, filter := range dt.filters {
+			query, err := ToSQL(filter, &ToSQLOptions{SearchMode: dt.mode})
+			assert.NoError(t, err, filter)
+			assert.Equal(t, dt.sql, query.Query, filter)
+			assert.Equal(t, dt.args, query.Args, filter)
+		}
+	}
+}
+
+func TestRebindQuery(t *testing.T) {
+	query, err := ToSQL(`((age: > 18 age: <= 25) OR (age:[19,20])) NOT (name:peter)`, &ToSQLOptions{})
+	assert.NoError(t, err)
+	rebound := query.Rebind(PlaceholderDollar)
+	assert.Equal(t, `(((age > $1 OR age <= $2) OR age IN ($3)) OR NOT name = $4)`, rebound.Query)
+	assert.Equal(t, []interface{}{18, 25, []interface{}{19, 20}, "peter"}, rebound.Args)
+	assert.Equal(t, query.Args, rebound.Args)
+	assert.Equal(t, `(((age > ? OR age <= ?) OR age IN (?)) OR NOT name = ?)`, query.Query)
+	assert.Equal(t, query, query.Rebind(PlaceholderQuestion))
+}
+
+func TestValidateQuery(t *testing.T) {
+	for _, filter := range []string{
+		`name: peter`,
+		`(name: peter OR age: >= 5) AND status: -closed`,
+		`name: pet* AND tags: [1, 2] AND age: [1 TO 5]`,
+	} {
+		query, err := ToSQL(filter, &ToSQLOptions{})
+		assert.NoError(t, err, filter)
+		assert.NoError(t, query.Validate(), filter)
+	}
+
+	cases := []Query{
+		{Query: `(name = ? AND age = ?`, Args: []interface{}{"peter", 5}},
+		{Query: `name = ?) AND (age = ?`, Args: []interface{}{"peter", 5}},
+		{Query: `name = ? AND age = ?`, Args: []interface{}{"peter"}},
+		{Query: `name = ?`, Args: []interface{}{"peter", 5}},
+		{Query: `(name = ? AND)`, Args: []interface{}{"peter"}},
+		{Query: `name = ? OR`, Args: []interface{}{"peter"}},
+		{Query: `AND name = ?`, Args: []interface{}{"peter"}},
+		{Query: `name = ? AND (OR age = ?)`, Args: []interface{}{"peter", 5}},
+	}
+	for _, query := range cases {
+		err := query.Validate()
+		assert.True(t, errors.Is(err, ErrInvalidQuery), "%s: %v", query.Query, err)
+	}
+	assert.NoError(t, Query{Query: `name = '(' AND age = ?`, Args: []interface{}{5}}.Validate())
+	assert.NoError(t, Query{Query: `NOT (name = ? OR age = ?)`, Args: []interface{}{"peter", 5}}.Validate())
+}
+
+func TestQueryTemplate(t *testing.T) {
+	opt := &ToSQLOptions{
+		InlineBooleans:     true,
+		MinimumShouldMatch: 2,
+		ColumnHandler: func(v interface{}) (Fragment, error) {
+			term := v.(lucenequery.TermQuery).Term
+			if term == "owner" {
+				return Fragment{Column: term, Query: "owner_id IN (SELECT id FROM users WHERE name = 'secret' AND level > 42)"}, nil
+			}
+			return Fragment{Column: term, Term: term}, nil
+		},
+	}
+	query, err := ToSQL(`name: peter* AND available: true AND owner: x AND (tag: a OR tag: b) AND deleted: false`, opt)
+	assert.NoError(t, err)
+	template := query.Template()
+	assert.Equal(t, `(name LIKE '?%' AND (available = ? AND (owner_id IN (SELECT id FROM users WHERE name = 'secret' AND level > 42) `+
+		`AND (((CASE WHEN tag = ? THEN 1 ELSE 0 END + CASE WHEN tag = ? THEN 1 ELSE 0 END) >= 2) AND deleted = ?))))`, template)
+	assert.Contains(t, query.Query, "available = TRUE")
+	for _, literal := range []string{"peter", "TRUE", "FALSE"} {
+		assert.NotContains(t, template, literal)
+	}
+	assert.Equal(t, template, query.Rebind(PlaceholderDollar).Template())
+
+	// generated constants and the bound queries are kept as is
+	for _, filter := range []string{`tags: []`, `tags: [] OR age: 1`, `name: peter`} {
+		query, err = ToSQL(filter, &ToSQLOptions{})
+		assert.NoError(t, err, filter)
+		assert.Equal(t, query.Query, query.Template(), filter)
+	}
+	query, err = ToSQL(`attrs: > 5`, &ToSQLOptions{ColumnHandler: func(field interface{}) (Fragment, error) {
+		return Fragment{Column: "attrs", Term: "attrs", JSONPath: "$.size"}, nil
+	}})
+	assert.NoError(t, err)
+	assert.Contains(t, query.Template(), "'min'")
+	assert.Equal(t, query.Query, query.Template())
+	assert.Equal(t, `(name = $1 AND age > -1.5e3)`, Query{Query: `(name = $1 AND age > -1.5e3)`}.Template())
+}
+
+func TestColumnFanOut(t *testing.T) {
+	opt := &ToSQLOptions{
+		ColumnHandler: func(field interface{}) (Fragment, error) {
+			switch f := field.(type) {
+			case lucenequery.TermQuery:
+				if f.Term == "q" {
+					return Fragment{Fragments: []Fragment{
+						{Term: "first_name", Column: "first_name"},
+						{Term: "last_name", Column: "last_name"},
+					}}, nil
+				}
+				return Fragment{Term: f.Term, Column: f.Term}, nil
+			case lucenequery.RangeQuery:
+				if f.Term == "created" {
+					return Fragment{Fragments: []Fragment{
+						{Term: "created_at", Column: "created_at"},
+						{Term: "updated_at", Column: "updated_at"},
+					}}, nil
+				}
+				return Fragment{Term: f.Term, Column: f.Term}, nil
+			}
+			return Fragment{}, fmt.Errorf("unknown type: %T", field)
+		},
+	}
+	cases := []struct {
+		filter  string
+		sql     string
+		args    []interface{}
+		columns []string
+	}{
+		{
+			filter:  `q: smith*`,
+			sql:     `(first_name LIKE '?%' OR last_name LIKE '?%')`,
+			args:    []interface{}{"smith", "smith"},
+			columns: []string{"first_name", "last_name"},
+		},
+		{
+			filter:  `age: > 18 q: -"smith"`,
+			sql:     `(age > ? OR NOT (first_name = ? OR last_name = ?))`,
+			args:    []interface{}{18, "smith", "smith"},
+			columns: []string{"age", "first_name", "last_name"},
+		},
+		{
+			filter:  `created: [2020 TO 2021]`,
+			sql:     `(created_at BETWEEN ? and ? OR updated_at BETWEEN ? and ?)`,
+			args:    []interface{}{2020, 2021, 2020, 2021},
+			columns: []string{"created_at", "updated_at"},
+		},
+	}
+	for _, dt := range cases {
+		query, err := ToSQL(dt.filter, opt)
+		assert.NoError(t, err, dt)
+		assert.Equal(t, dt.sql, query.Query, dt)
+		assert.Equal(t, dt.args, query.Args, dt)
+		assert.Equal(t, dt.columns, query.Columns, dt)
+	}
+}
+
+type castGenerator struct {
+	*Generator
+}
+
+func (g *castGenerator) VisitTerm(node lucenequery.TermQuery) (Query, error) {
+	if node.Term != "age" {
+		return g.Generator.VisitTerm(node)
+	}
+	return Query{
+		Query:   "age = CAST(? AS INTEGER)",
+		Args:    []interface{}{node.Value},
+		Columns: []string{"age"},
+	}, nil
+}
+
+func TestGeneratorVisitor(t *testing.T) {
+	gen := NewGenerator(&ToSQLOptions{})
+	gen.Visitor = &castGenerator{Generator: gen}
+	query, err := gen.Generate(`name: peter AND (age: "18" OR name: pet*)`)
+	assert.NoError(t, err)
+	assert.Equal(t, `(name = ? AND (age = CAST(? AS INTEGER) OR name LIKE '?%'))`, query.Query)
+	assert.Equal(t, []interface{}{"peter", "18", "pet"}, query.Args)
+	assert.Equal(t, []string{"name", "age"}, query.Columns)
+
+	query, err = ToSQL(`name: peter AND (age: "18" OR name: pet*)`, &ToSQLOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, `(name = ? AND (age = ? OR name LIKE '?%'))`, query.Query)
+}
+
+func TestRawFragment(t *testing.T) {
+	exists := "EXISTS (SELECT 1 FROM tags WHERE tags.post_id = posts.id AND tags.kind = ? AND tags.name = ?)"
+	handler := func(field interface{}) (Fragment, error) {
+		switch f := field.(type) {
+		case lucenequery.TermQuery:
+			if f.Term == "tag" {
+				return Fragment{Column: "tags", Query: exists, Args: []interface{}{"label", f.Value}}, nil
+			}
+			return Fragment{Term: f.Term, Column: f.Term}, nil
+		case lucenequery.RangeQuery:
+			if f.Term == "comments" {
+				return Fragment{
+					Column: "comments",
+					Query:  "(SELECT COUNT(*) FROM comments WHERE comments.post_id = posts.id) >= ?",
+					Args:   []interface{}{f.Min},
+				}, nil
+			}
+			return Fragment{Term: f.Term, Column: f.Term}, nil
+		}
+		return Fragment{}, fmt.Errorf("unknown type: %T", field)
+	}
+	cases := []struct {
+		filter interface{}
+		sql    string
+		args   []interface{}
+		opt    *ToSQLOptions
+	}{
+		{
+			filter: `tag: go`,
+			sql:    exists,
+			args:   []interface{}{"label", "go"},
+		},
+		{
+			filter: `status: open AND tag: go AND author: peter`,
+			sql:    "(status = ? AND (" + exists + " AND author = ?))",
+			args:   []interface{}{"open", "label", "go", "peter"},
+		},
+		{
+			filter: `status: open tag: -go`,
+			sql:    "(status = ? AND NOT " + exists + ")",
+			args:   []interface{}{"open", "label", "go"},
+			opt:    &ToSQLOptions{SearchMode: SearchModeAll},
+		},
+		{
+			filter: `comments: >= 5 OR tag: go`,
+			sql:    "((SELECT COUNT(*) FROM comments WHERE comments.post_id = posts.id) >= ? OR " + exists + ")",
+			args:   []interface{}{5, "label", "go"},
+		},
+	}
+	for _, dt := range cases {
+		opt := &ToSQLOptions{}
+		if dt.opt != nil {
+			opt = dt.opt
+		}
+		opt.ColumnHandler = handler
+		query, err := ToSQL(dt.filter, opt)
+		assert.NoError(t, err, dt)
+		assert.Equal(t, dt.sql, query.Query, dt)
+		assert.Equal(t, dt.args, query.Args, dt)
+	}
+}
+
+func TestFieldAliases(t *testing.T) {
+	var seen []string
+	allowed := map[string]bool{"created_by": true, "age": true}
+	opt := &ToSQLOptions{
+		FieldAliases: map[string]string{"author": "created_by", "years": "age"},
+		ColumnHandler: func(field interface{}) (Fragment, error) {
+			var name string
+			switch f := field.(type) {
+			case lucenequery.TermQuery:
+				name = f.Term
+			case lucenequery.RangeQuery:
+				name = f.Term
+			}
+			seen = append(seen, name)
+			if !allowed[name] {
+				return Fragment{}, fmt.Errorf("field `%s` is not allowed", name)
+			}
+			return Fragment{Term: name, Column: name}, nil
+		},
+	}
+	query, err := ToSQL(`author: peter AND years: > 18`, opt)
+	assert.NoError(t, err)
+	assert.Equal(t, `(created_by = ? AND age > ?)`, query.Query)
+	assert.Equal(t, []interface{}{"peter", 18}, query.Args)
+	assert.Equal(t, []string{"created_by", "age"}, query.Columns)
+	assert.Equal(t, []string{"created_by", "age"}, seen)
+
+	_, err = ToSQL(`created: > 18`, opt)
+	assert.Error(t, err)
+}
+
+func TestBindHook(t *testing.T) {
+	type bound struct {
+		index int
+		op    string
+	}
+	var calls []bound
+	opt := &ToSQLOptions{
+		BindHook: func(index int, op string, value interface{}) (interface{}, error) {
+			calls = append(calls, bound{index: index, op: op})
+			if s, ok := value.(string); ok && op == "=" {
+				return fmt.Sprintf("enc(%s)", s), nil
+			}
+			return value, nil
+		},
+	}
+	query, err := ToSQL(`(ssn: "123-45" OR name: pet*) AND age: [18 TO 25] AND email: "a@b.c"`, opt)
+	assert.NoError(t, err)
+	assert.Equal(t, `((ssn = ? OR name LIKE '?%') AND (age BETWEEN ? and ? AND email = ?))`, query.Query)
+	assert.Equal(t, []interface{}{"enc(123-45)", "pet", 18, 25, "enc(a@b.c)"}, query.Args)
+	assert.Equal(t, []bound{{0, "="}, {1, "LIKE"}, {2, "BETWEEN"}, {3, "BETWEEN"}, {4, "="}}, calls)
+
+	opt.BindHook = func(index int, op string, value interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("invalid value")
+	}
+	_, err = ToSQL(`ssn: "123-45"`, opt)
+	assert.Error(t, err)
+}
+
+func TestUnknownOperator(t *testing.T) {
+	filter := lucenequery.BooleanExpression{
+		Op: "AND",
+		Args: []interface{}{
+			lucenequery.TermQuery{Term: "name", Value: "peter"},
+			lucenequery.TermQuery{Term: "age", Value: 5, Op: "near"},
+		},
+	}
+	for _, policy := range []OperatorPolicy{OperatorPolicyFallback, OperatorPolicyWarn} {
+		query, err := ToSQL(filter, &ToSQLOptions{OnUnknownOperator: policy})
+		assert.NoError(t, err, policy)
+		assert.Equal(t, `(name = ? AND age = ?)`, query.Query, policy)
+		assert.Equal(t, []interface{}{"peter", 5}, query.Args, policy)
+	}
+
+	_, err := ToSQL(filter, &ToSQLOptions{OnUnknownOperator: OperatorPolicyError})
+	assert.True(t, errors.Is(err, ErrUnknownOperator), err)
+
+	query, err := ToSQL(`age: >= 5`, &ToSQLOptions{OnUnknownOperator: OperatorPolicyError})
+	assert.NoError(t, err)
+	assert.Equal(t, `age >= ?`, query.Query)
+}
+
+func TestWildcardChars(t *testing.T) {
+	cases := []struct {
+		filter string
+		sql    string
+		args   []interface{}
+	}{
+		{
+			filter: `name: abc*`,
+			sql:    `name LIKE '?*'`,
+			args:   []interface{}{"abc"},
+		},
+		{
+			filter: `name: *abc`,
+			sql:    `name LIKE '*?'`,
+			args:   []interface{}{"abc"},
+		},
+		{
+			filter: `name: ab*c`,
+			sql:    `name LIKE '?*?'`,
+			args:   []interface{}{"ab", "c"},
+		},
+		{
+			filter: `name: a?b*`,
+			sql:    `name LIKE '?*'`,
+			args:   []interface{}{`a\?b`},
+		},
+		{
+			filter: `name: off%_x*`,
+			sql:    `name LIKE '?*'`,
+			args:   []interface{}{"off%_x"},
+		},
+	}
+	for _, dt := range cases {
+		query, err := ToSQL(dt.filter, &ToSQLOptions{
+			WildcardChars: WildcardChars{Any: "*", Single: "?"},
+		})
+		assert.NoError(t, err, dt)
+		assert.Equal(t, dt.sql, query.Query, dt)
+		assert.Equal(t, dt.args, query.Args, dt)
+	}
+
+	query, err := ToSQL(`name: off%_x*`, &ToSQLOptions{
+		WildcardChars: WildcardChars{Any: "%", Single: "_"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `name LIKE '?%'`, query.Query)
+	assert.Equal(t, []interface{}{`off\%\_x`}, query.Args)
+}
+
+func TestWildcardEscape(t *testing.T) {
+	cases := []struct {
+		filter string
+		chars  WildcardChars
+		sql    string
+		args   []interface{}
+	}{
+		{`path: "C:\\\\temp\\\\"*`, WildcardChars{Escape: "!"}, `path LIKE '?%' ESCAPE '!'`, []interface{}{`C:\\temp\\`}},
+		{`name: "100%!"*`, WildcardChars{Escape: "!"}, `name LIKE '?%' ESCAPE '!'`, []interface{}{`100!%!!`}},
+		{`name: *a_b*`, WildcardChars{Escape: "!"}, `name LIKE '%?%' ESCAPE '!'`, []interface{}{`a!_b`}},
+		{`name: ab*c`, WildcardChars{Any: "*", Single: "?", Escape: "#"}, `name LIKE '?*?' ESCAPE '#'`, []interface{}{"ab", "c"}},
+		{`name: *`, WildcardChars{Escape: "!"}, `name IS NOT NULL`, []interface{}{}},
+	}
+	for _, tc := range cases {
+		query, err := ToSQL(tc.filter, &ToSQLOptions{WildcardChars: tc.chars})
+		assert.NoError(t, err, tc.filter)
+		assert.Equal(t, tc.sql, query.Query, tc.filter)
+		assert.Equal(t, tc.args, query.Args, tc.filter)
+	}
+}
+
+func TestRawValues(t *testing.T) {
+	doc := json.RawMessage(`{"tags": ["a", "b"]}`)
+	filter := lucenequery.BooleanExpression{
+		Op: "AND",
+		Args: []interface{}{
+			lucenequery.TermQuery{Term: "data", Value: doc},
+			lucenequery.TermQuery{Term: "hash", Value: []byte{0x01, 0x02}},
+		},
+	}
+	query, err := ToSQL(filter, &ToSQLOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, `(data = ? AND hash = ?)`, query.Query)
+	assert.Equal(t, []interface{}{doc, []byte{0x01, 0x02}}, query.Args)
+
+	query, err = ToSQL(filter, &ToSQLOptions{
+		ColumnHandler: func(v interface{}) (Fragment, error) {
+			term := v.(lucenequery.TermQuery).Term
+			if term == "data" {
+				return Fragment{Column: term, Term: term, Cast: "jsonb"}, nil
+			}
+			return Fragment{Column: term, Term: term}, nil
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `(data = CAST(? AS jsonb) AND hash = ?)`, query.Query)
+	assert.Equal(t, []interface{}{doc, []byte{0x01, 0x02}}, query.Args)
+	assert.IsType(t, json.RawMessage{}, query.Args[0])
+}
+
+func TestMaxQueryLength(t *testing.T) {
+	filter := `name: peter AND (age: 5 OR age: 6)`
+	query, err := ToSQL(filter, &ToSQLOptions{MaxQueryLength: 40})
+	assert.NoError(t, err)
+	assert.Equal(t, `(name = ? AND (age = ? OR age = ?))`, query.Query)
+
+	query, err = ToSQL(filter, &ToSQLOptions{MaxQueryLength: 20})
+	assert.True(t, errors.Is(err, ErrQueryTooLong), err)
+	assert.Empty(t, query.Query)
+	assert.Empty(t, query.Args)
+}
+
+func TestMinimumShouldMatch(t *testing.T) {
+	cases := []struct {
+		filter string
+		opt    ToSQLOptions
+		sql    string
+		args   []interface{}
+	}{
+		{
+			filter: `name: peter OR age: 5 OR city: nairobi`,
+			opt:    ToSQLOptions{MinimumShouldMatch: 2},
+			sql:    `((CASE WHEN name = ? THEN 1 ELSE 0 END + CASE WHEN age = ? THEN 1 ELSE 0 END + CASE WHEN city = ? THEN 1 ELSE 0 END) >= 2)`,
+			args:   []interface{}{"peter", 5, "nairobi"},
+		},
+		{
+			filter: `name: peter age: 5 city: nairobi`,
+			opt:    ToSQLOptions{MinimumShouldMatch: 2},
+			sql:    `((CASE WHEN name = ? THEN 1 ELSE 0 END + CASE WHEN age = ? THEN 1 ELSE 0 END + CASE WHEN city = ? THEN 1 ELSE 0 END) >= 2)`,
+			args:   []interface{}{"peter", 5, "nairobi"},
+		},
+		{
+			filter: `status: open AND (name: peter OR age: 5)`,
+			opt:    ToSQLOptions{MinimumShouldMatch: 2},
+			sql:    `(status = ? AND ((CASE WHEN name = ? THEN 1 ELSE 0 END + CASE WHEN age = ? THEN 1 ELSE 0 END) >= 2))`,
+			args:   []interface{}{"open", "peter", 5},
+		},
+		{
+			filter: `name: peter OR age: 5`,
+			opt:    ToSQLOptions{MinimumShouldMatch: 3},
+			sql:    `1 = 0`,
+			args:   []interface{}{},
+		},
+		{
+			filter: `name: peter age: 5 city: nairobi`,
+			opt:    ToSQLOptions{MinimumShouldMatch: 2, SearchMode: SearchModeAll},
+			sql:    `(name = ? AND (age = ? AND city = ?))`,
+			args:   []interface{}{"peter", 5, "nairobi"},
+		},
+		{
+			filter: `name: peter OR age: 5 OR city: nairobi`,
+			opt:    ToSQLOptions{MinimumShouldMatch: 1},
+			sql:    `(name = ? OR (age = ? OR city = ?))`,
+			args:   []interface{}{"peter", 5, "nairobi"},
+		},
+	}
+	for _, dt := range cases {
+		query, err := ToSQL(dt.filter, &dt.opt)
+		assert.NoError(t, err, dt)
+		assert.Equal(t, dt.sql, query.Query, dt)
+		assert.Equal(t, dt.args, query.Args, dt)
+	}
+}
+
+func TestRangeCast(t *testing.T) {
+	cases := []struct {
+		filter string
+		sql    string
+		args   []interface{}
+	}{
+		{
+			filter: `created: ["2021-01-01" TO "2021-02-01"]`,
+			sql:    `created BETWEEN CAST(? AS timestamptz) and CAST(? AS timestamptz)`,
+			args:   []interface{}{"2021-01-01", "2021-02-01"},
+		},
+		{
+			filter: `created: {"2021-01-01" TO "2021-02-01"}`,
+			sql:    `created > CAST(? AS timestamptz) and created < CAST(? AS timestamptz)`,
+			args:   []interface{}{"2021-01-01", "2021-02-01"},
+		},
+		{
+			filter: `created: >= "2021-01-01" AND age: > 5`,
+			sql:    `(created >= CAST(? AS timestamptz) AND age > ?)`,
+			args:   []interface{}{"2021-01-01", 5},
+		},
+	}
+	opt := &ToSQLOptions{
+		ColumnHandler: func(v interface{}) (Fragment, error) {
+			term := v.(lucenequery.RangeQuery).Term
+			if term == "created" {
+				return Fragment{Column: term, Term: term, Cast: "timestamptz"}, nil
+			}
+			return Fragment{Column: term, Term: term}, nil
+		},
+	}
+	for _, dt := range cases {
+		query, err := ToSQL(dt.filter, opt)
+		assert.NoError(t, err, dt)
+		assert.Equal(t, dt.sql, query.Query, dt)
+		assert.Equal(t, dt.args, query.Args, dt)
+	}
+}
+
+func TestInlineBooleans(t *testing.T) {
+	cases := []struct {
+		opt  ToSQLOptions
+		sql  string
+		args []interface{}
+	}{
+		{
+			opt:  ToSQLOptions{},
+			sql:  `(available = ? AND (deleted <> ? AND name = ?))`,
+			args: []interface{}{true, false, "peter"},
+		},
+		{
+			opt:  ToSQLOptions{Dialect: DialectMySQL},
+			sql:  `(available = ? AND (deleted <> ? AND name = ?))`,
+			args: []interface{}{true, false, "peter"},
+		},
+		{
+			opt:  ToSQLOptions{InlineBooleans: true},
+			sql:  `(available = TRUE AND (deleted <> FALSE AND name = ?))`,
+			args: []interface{}{"peter"},
+		},
+		{
+			opt:  ToSQLOptions{InlineBooleans: true, Dialect: DialectPostgres},
+			sql:  `(available = TRUE AND (deleted <> FALSE AND name = ?))`,
+			args: []interface{}{"peter"},
+		},
+		{
+			opt:  ToSQLOptions{InlineBooleans: true, Dialect: DialectSQLite},
+			sql:  `(available = 1 AND (deleted <> 0 AND name = ?))`,
+			args: []interface{}{"peter"},
+		},
+	}
+	for _, dt := range cases {
+		query, err := ToSQL(`available: true AND deleted: != false AND name: peter`, &dt.opt)
+		assert.NoError(t, err, dt)
+		assert.Equal(t, dt.sql, query.Query, dt)
+		assert.Equal(t, dt.args, query.Args, dt)
+	}
+}
+
+func TestBooleanTests(t *testing.T) {
+	cases := []struct {
+		filter string
+		sql    string
+		args   []interface{}
+	}{
+		{`active: true`, `active IS TRUE`, []interface{}{}},
+		{`active: false`, `active IS FALSE`, []interface{}{}},
+		{`-active: true`, `active IS NOT TRUE`, []interface{}{}},
+		{`active: -false`, `active IS NOT FALSE`, []interface{}{}},
+		{`active: != true`, `active IS NOT TRUE`, []interface{}{}},
+		{`active: true AND name: peter`, `(active IS TRUE AND name = ?)`, []interface{}{"peter"}},
+		{`active: null`, `active IS NULL`, []interface{}{}},
+		{`name: peter +active: true`, `(name = ? AND active IS TRUE)`, []interface{}{"peter"}},
+		{`name: peter -active: true`, `(name = ? OR active IS NOT TRUE)`, []interface{}{"peter"}},
+		{`+active: true`, `active IS TRUE`, []interface{}{}},
+	}
+	for _, dt := range cases {
+		query, err := ToSQL(dt.filter, &ToSQLOptions{BooleanTests: true, InlineBooleans: true})
+		assert.NoError(t, err, dt.filter)
+		assert.Equal(t, dt.sql, query.Query, dt.filter)
+		assert.Equal(t, dt.args, query.Args, dt.filter)
+	}
+}
+
+func TestAllFields(t *testing.T) {
+	cases := []struct {
+		filter  string
+		sql     string
+		args    []interface{}
+		columns []string
+	}{
+		{
+			filter:  `@all: foo*`,
+			sql:     `(title LIKE '?%' OR body LIKE '?%')`,
+			args:    []interface{}{"foo", "foo"},
+			columns: []string{"title", "body"},
+		},
+		{
+			filter:  `@all: "foo*"`,
+			sql:     `(title LIKE '?%' OR body LIKE '?%')`,
+			args:    []interface{}{"foo", "foo"},
+			columns: []string{"title", "body"},
+		},
+		{
+			filter:  `@all: "*foo*"`,
+			sql:     `(title LIKE '%?%' OR body LIKE '%?%')`,
+			args:    []interface{}{"foo", "foo"},
+			columns: []string{"title", "body"},
+		},
+		{
+			filter:  `@all: "a*b"`,
+			sql:     `(title = ? OR body = ?)`,
+			args:    []interface{}{"a*b", "a*b"},
+			columns: []string{"title", "body"},
+		},
+		{
+			filter:  `@all: peter AND age: 5`,
+			sql:     `((title = ? OR body = ?) AND age = ?)`,
+			args:    []interface{}{"peter", "peter", 5},
+			columns: []string{"title", "body", "age"},
+		},
+		{
+			filter:  `@all: [a TO c]`,
+			sql:     `(title BETWEEN ? and ? OR body BETWEEN ? and ?)`,
+			args:    []interface{}{"a", "c", "a", "c"},
+			columns: []string{"title", "body"},
+		},
+	}
+	for _, dt := range cases {
+		query, err := ToSQL(dt.filter, &ToSQLOptions{AllFields: []string{"title", "body"}})
+		assert.NoError(t, err, dt)
+		assert.Equal(t, dt.sql, query.Query, dt)
+		assert.Equal(t, dt.args, query.Args, dt)
+		assert.Equal(t, dt.columns, query.Columns, dt)
+	}
+
+	query, err := ToSQL(`@all: peter`, &ToSQLOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, `@all = ?`, query.Query)
+
+	query, err = ToSQL(`title: "foo*"`, &ToSQLOptions{AllFields: []string{"title", "body"}})
+	assert.NoError(t, err)
+	assert.Equal(t, `title = ?`, query.Query)
+	assert.Equal(t, []interface{}{"foo*"}, query.Args)
+}
+
+func TestInHandlers(t *testing.T) {
+	cases := []struct {
+		handler InHandler
+		args    []interface{}
+	}{
+		{handler: nil, args: []interface{}{[]interface{}{1, "2", 3.0, "x"}}},
+		{handler: InStrings, args: []interface{}{[]interface{}{"1", "2", "3", "x"}}},
+		{handler: InInts, args: []interface{}{[]interface{}{1, 2, 3, "x"}}},
+	}
+	for _, dt := range cases {
+		query, err := ToSQL(`id: [1, "2", 3.0, "x"]`, &ToSQLOptions{InHandler: dt.handler})
+		assert.NoError(t, err)
+		assert.Equal(t, `id IN (?)`, query.Query)
+		assert.Equal(t, dt.args, query.Args)
+	}
+}
+
+func TestJSONColumns(t *testing.T) {
+	cases := []struct {
+		filter  string
+		sql     string
+		args    []interface{}
+		columns []string
+	}{
+		{
+			filter:  `labels.env: prod`,
+			sql:     `labels->>'env' = ?`,
+			args:    []interface{}{"prod"},
+			columns: []string{"labels.env"},
+		},
+		{
+			filter:  `stats.score: [0 TO 100]`,
+			sql:     `CAST(stats->>'score' AS numeric) BETWEEN ? and ?`,
+			args:    []interface{}{0, 100},
+			columns: []string{"stats.score"},
+		},
+		{
+			filter:  `stats.score: > 5 AND stats.detail.grade: ["a" TO "c"]`,
+			sql:     `(CAST(stats->>'score' AS numeric) > ? AND stats->'detail'->>'grade' BETWEEN ? and ?)`,
+			args:    []interface{}{5, "a", "c"},
+			columns: []string{"stats.score", "stats.detail.grade"},
+		},
+		{
+			filter:  `other.score: [0 TO 100]`,
+			sql:     `other.score BETWEEN ? and ?`,
+			args:    []interface{}{0, 100},
+			columns: []string{"other.score"},
+		},
+	}
+	for _, dt := range cases {
+		query, err := ToSQL(dt.filter, &ToSQLOptions{JSONColumns: []string{"labels", "stats"}})
+		assert.NoError(t, err, dt)
+		assert.Equal(t, dt.sql, query.Query, dt)
+		assert.Equal(t, dt.args, query.Args, dt)
+		assert.Equal(t, dt.columns, query.Columns, dt)
+	}
+}
+
+func TestWrapResult(t *testing.T) {
+	cases := []struct {
+		filter string
+		wrap   WrapResult
+		sql    string
+	}{
+		{filter: `name: peter`, wrap: WrapResultDefault, sql: `name = ?`},
+		{filter: `name: peter`, wrap: WrapResultNever, sql: `name = ?`},
+		{filter: `name: peter`, wrap: WrapResultAlways, sql: `(name = ?)`},
+		{filter: `name: peter`, wrap: WrapResultWhenMultiple, sql: `name = ?`},
+		{filter: `name: peter AND age: 5`, wrap: WrapResultDefault, sql: `(name = ? AND age = ?)`},
+		{filter: `name: peter AND age: 5`, wrap: WrapResultNever, sql: `name = ? AND age = ?`},
+		{filter: `name: peter AND age: 5`, wrap: WrapResultAlways, sql: `(name = ? AND age = ?)`},
+		{filter: `name: peter AND age: 5`, wrap: WrapResultWhenMultiple, sql: `(name = ? AND age = ?)`},
+		{filter: `(name: peter)`, wrap: WrapResultWhenMultiple, sql: `name = ?`},
+		{filter: `age: [1 TO 5]`, wrap: WrapResultWhenMultiple, sql: `age BETWEEN ? and ?`},
+		{filter: `NOT (name: peter OR age: 5)`, wrap: WrapResultNever, sql: `NOT (name = ? OR age = ?)`},
+		{filter: `NOT (name: peter OR age: 5)`, wrap: WrapResultAlways, sql: `(NOT (name = ? OR age = ?))`},
+		{filter: `(a: 1 OR b: 2) AND (c: 3 OR d: 4)`, wrap: WrapResultNever, sql: `(a = ? OR b = ?) AND (c = ? OR d = ?)`},
+	}
+	for _, dt := range cases {
+		query, err := ToSQL(dt.filter, &ToSQLOptions{WrapResult: dt.wrap})
+		assert.NoError(t, err, dt)
+		assert.Equal(t, dt.sql, query.Query, dt)
+	}
+}
+
+func TestColumnsOrder(t *testing.T) {
+	cases := []struct {
+		filter  string
+		columns []string
+	}{
+		{filter: `name: a name: b`, columns: []string{"name"}},
+		{filter: `(name: a OR age: 1) AND (status: open OR name: b OR age: 2)`, columns: []string{"name", "age", "status"}},
+		{filter: `age: [1 TO 5] AND (name: a OR (age: > 10 AND name: b))`, columns: []string{"age", "name"}},
+	}
+	for _, dt := range cases {
+		query, err := ToSQL(dt.filter, &ToSQLOptions{})
+		assert.NoError(t, err, dt.filter)
+		assert.Equal(t, dt.columns, query.Columns, dt.filter)
+	}
+}
+
+type schemaBase struct {
+	ID int `json:"id" db:"user_id"`
+}
+
+type schemaUser struct {
+	schemaBase
+	Name     string `json:"name" db:"full_name"`
+	Email    string `json:"email"`
+	Age      int    `db:"age"`
+	Password string `json:"-" db:"-"`
+	Internal string
+}
+
+func TestSchema(t *testing.T) {
+	cases := []struct {
+		filter  string
+		sql     string
+		columns []string
+	}{
+		{filter: `name: peter`, sql: `full_name = ?`, columns: []string{"full_name"}},
+		{filter: `id: 5 AND age: [18 TO 25] AND email: "a@b.c"`, sql: `(user_id = ? AND (age BETWEEN ? and ? AND email = ?))`, columns: []string{"user_id", "age", "email"}},
+	}
+	for _, schema := range []interface{}{schemaUser{}, &schemaUser{}, reflect.TypeOf(schemaUser{})} {
+		for _, dt := range cases {
+			query, err := ToSQL(dt.filter, &ToSQLOptions{Schema: schema})
+			assert.NoError(t, err, dt.filter)
+			assert.Equal(t, dt.sql, query.Query, dt.filter)
+			assert.Equal(t, dt.columns, query.Columns, dt.filter)
+		}
+		for _, filter := range []string{`password: x`, `Internal: x`, `full_name: peter`, `name: peter AND Password: [1 TO 2]`} {
+			_, err := ToSQL(filter, &ToSQLOptions{Schema: schema})
+			assert.Error(t, err, filter)
+		}
+	}
+}
+
+func TestArrayQuantifiers(t *testing.T) {
+	cases := []struct {
+		query    string
+		expected Query
+	}{
+		{`tags: all ["a", "b"]`, Query{Query: "tags @> ?", Args: []interface{}{[]interface{}{"a", "b"}}, Columns: []string{"tags"}}},
+		{`tags: any ["a", "b"]`, Query{Query: "tags && ?", Args: []interface{}{[]interface{}{"a", "b"}}, Columns: []string{"tags"}}},
+		{`-tags: any ["a"]`, Query{Query: "NOT tags && ?", Args: []interface{}{[]interface{}{"a"}}, Columns: []string{"tags"}}},
+		{`tags: all []`, Query{Query: "1 = 1", Args: []interface{}{}, Columns: []string{"tags"}}},
+		{`tags: any []`, Query{Query: "1 = 0", Args: []interface{}{}, Columns: []string{"tags"}}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, &ToSQLOptions{})
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.expected, q, tc.query)
+	}
+
+	q, err := ToSQL(`tags: any ["a", 2]`, &ToSQLOptions{InHandler: InStrings})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{"a", "2"}}, q.Args)
+}
+
+func TestPrettyQuery(t *testing.T) {
+	query := `a: 1 OR (b: 2 AND age: [18 TO 25] AND (c: 3 OR -d: 4))`
+	compact, err := ToSQL(query, &ToSQLOptions{})
+	assert.NoError(t, err)
+	q, err := ToSQL(query, &ToSQLOptions{Pretty: true})
+	assert.NoError(t, err)
+
+	expected := `(
+  a = ?
+  OR (
+    b = ?
+    AND (
+      age BETWEEN ? and ?
+      AND (
+        c = ?
+        OR NOT d = ?
+      )
+    )
+  )
+)`
+	assert.Equal(t, expected, q.Query)
+	assert.Equal(t, compact.Args, q.Args)
+	assert.Equal(t, compact.Columns, q.Columns)
+	assert.Equal(t, "(a = ? OR (b = ? AND (age BETWEEN ? and ? AND (c = ? OR NOT d = ?))))", compact.Query)
+}
+
+func TestNullCoalesce(t *testing.T) {
+	cases := []struct {
+		query    string
+		expected Query
+	}{
+		{`status ?? "open"`, Query{Query: "(status = ? OR status IS NULL)", Args: []interface{}{"open"}, Columns: []string{"status"}}},
+		{`status: ?? 2 AND name: john`, Query{Query: "((status = ? OR status IS NULL) AND name = ?)", Args: []interface{}{2, "john"}, Columns: []string{"status", "name"}}},
+		{`status ?? null`, Query{Query: "status IS NULL", Args: []interface{}{}, Columns: []string{"status"}}},
+		{`-status ?? open`, Query{Query: "NOT (status = ? OR status IS NULL)", Args: []interface{}{"open"}, Columns: []string{"status"}}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, &ToSQLOptions{})
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.expected, q, tc.query)
+	}
+}
+
+func TestBuilderQuery(t *testing.T) {
+	node := lucenequery.New().Term("status", "open").And().Range("age", 18, 25).Build()
+	q, err := ToSQL(node, &ToSQLOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, Query{Query: "(status = ? AND age BETWEEN ? and ?)", Args: []interface{}{"open", 18, 25}, Columns: []string{"status", "age"}}, q)
+}
+
+func TestExistsRelationship(t *testing.T) {
+	opt := &ToSQLOptions{
+		ColumnHandler: func(field interface{}) (Fragment, error) {
+			var name string
+			switch v := field.(type) {
+			case lucenequery.TermQuery:
+				name = v.Term
+			case lucenequery.RangeQuery:
+				name = v.Term
+			}
+			if strings.HasPrefix(name, "comments.") {
+				return Fragment{
+					Column: name,
+					Term:   name,
+					Exists: "SELECT 1 FROM comments WHERE comments.post_id = posts.id",
+				}, nil
+			}
+			return Fragment{Column: name, Term: "posts." + name}, nil
+		},
+	}
+	cases := []struct {
+		query    string
+		expected Query
+	}{
+		{`comments.author: "x"`, Query{
+			Query:   "EXISTS (SELECT 1 FROM comments WHERE comments.post_id = posts.id AND comments.author = ?)",
+			Args:    []interface{}{"x"},
+			Columns: []string{"comments.author"},
+		}},
+		{`title: go AND comments.author: "x"`, Query{
+			Query:   "(posts.title = ? AND EXISTS (SELECT 1 FROM comments WHERE comments.post_id = posts.id AND comments.author = ?))",
+			Args:    []interface{}{"go", "x"},
+			Columns: []string{"title", "comments.author"},
+		}},
+		{`-comments.author: "x"`, Query{
+			Query:   "NOT EXISTS (SELECT 1 FROM comments WHERE comments.post_id = posts.id AND comments.author = ?)",
+			Args:    []interface{}{"x"},
+			Columns: []string{"comments.author"},
+		}},
+		{`comments.votes: [1 TO 5]`, Query{
+			Query:   "EXISTS (SELECT 1 FROM comments WHERE comments.post_id = posts.id AND comments.votes BETWEEN ? and ?)",
+			Args:    []interface{}{1, 5},
+			Columns: []string{"comments.votes"},
+		}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.expected, q, tc.query)
+	}
+}
+
+func TestTrimValues(t *testing.T) {
+	cases := []struct {
+		query string
+		opt   ToSQLOptions
+		sql   string
+		args  []interface{}
+	}{
+		{`name: " peter "`, ToSQLOptions{}, `name = ?`, []interface{}{" peter "}},
+		{`name: " peter "`, ToSQLOptions{TrimValues: true}, `name = ?`, []interface{}{"peter"}},
+		{`name: [" a ", "b "]`, ToSQLOptions{TrimValues: true}, `name IN (?)`, []interface{}{[]interface{}{"a", "b"}}},
+		{`name: [" a" TO "b "]`, ToSQLOptions{TrimValues: true}, `name BETWEEN ? and ?`, []interface{}{"a", "b"}},
+		{`age: 5`, ToSQLOptions{TrimValues: true}, `age = ?`, []interface{}{5}},
+		{`name: "peter "*`, ToSQLOptions{TrimValues: true}, `name LIKE '?%'`, []interface{}{"peter "}},
+		{`name: *" peter "`, ToSQLOptions{TrimValues: true}, `name LIKE '%?'`, []interface{}{" peter "}},
+		{`name: *" peter "`, ToSQLOptions{TrimValues: true, TrimPatterns: true}, `name LIKE '%?'`, []interface{}{"peter"}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, &tc.opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+}
+
+func TestMapFilter(t *testing.T) {
+	created := time.Date(2021, 3, 1, 15, 4, 5, 0, time.FixedZone("EAT", 3*60*60))
+	cases := []struct {
+		filter map[string]interface{}
+		opt    ToSQLOptions
+		sql    string
+		args   []interface{}
+	}{
+		{map[string]interface{}{"created": created}, ToSQLOptions{}, `created = ?`, []interface{}{created}},
+		{
+			map[string]interface{}{"status": "open", "created": created, "deleted": nil},
+			ToSQLOptions{},
+			`(created = ? AND (deleted IS NULL AND status = ?))`,
+			[]interface{}{created, "open"},
+		},
+		{
+			map[string]interface{}{"tags": []string{"a", "b"}, "hash": []byte{0x01}},
+			ToSQLOptions{},
+			`(hash = ? AND tags IN (?))`,
+			[]interface{}{[]byte{0x01}, []interface{}{"a", "b"}},
+		},
+		{map[string]interface{}{}, ToSQLOptions{}, ``, []interface{}{}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.filter, &tc.opt)
+		assert.NoError(t, err, tc.filter)
+		assert.Equal(t, tc.sql, q.Query, tc.filter)
+		assert.Equal(t, tc.args, q.Args, tc.filter)
+	}
+
+	q, err := ToSQL(map[string]interface{}{"created": created}, &ToSQLOptions{Location: time.UTC})
+	assert.NoError(t, err)
+	assert.Equal(t, `created = ?`, q.Query)
+	assert.Equal(t, []interface{}{created.In(time.UTC)}, q.Args)
+	assert.Equal(t, time.UTC, q.Args[0].(time.Time).Location())
+	assert.True(t, created.Equal(q.Args[0].(time.Time)))
+
+	q, err = ToSQL(map[string]interface{}{"created": []interface{}{created}}, &ToSQLOptions{Location: time.UTC})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{created.In(time.UTC)}}, q.Args)
+}
+
+func TestTimeAnchors(t *testing.T) {
+	now := time.Date(2021, 3, 1, 15, 4, 5, 0, time.UTC)
+	today, yesterday := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		query string
+		sql   string
+		args  []interface{}
+	}{
+		{`created: < today`, `created < ?`, []interface{}{today}},
+		{`created: >= yesterday`, `created >= ?`, []interface{}{yesterday}},
+		{`created: [yesterday TO today]`, `created BETWEEN ? and ?`, []interface{}{yesterday, today}},
+		{`created: <= now`, `created <= ?`, []interface{}{now}},
+		{`title: today`, `title = ?`, []interface{}{"today"}},
+		{`created: [yesterday TO *]`, `created >= ?`, []interface{}{yesterday}},
+		{`name: [now TO zebra]`, `name BETWEEN ? and ?`, []interface{}{"now", "zebra"}},
+	}
+	opt := &ToSQLOptions{Now: func() time.Time { return now }}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+}
+
+func TestQueryString(t *testing.T) {
+	q, err := ToSQL(`name: peter AND age: > 18`, &ToSQLOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, q.Query, q.String())
+	assert.Equal(t, "(name = ? AND age > ?)", fmt.Sprint(q))
+	assert.Equal(t, "", Query{}.String())
+}
+
+func TestWhereClause(t *testing.T) {
+	// where has the signature of the gorm `DB.Where` method
+	var conditions []string
+	var bound []interface{}
+	where := func(query interface{}, args ...interface{}) {
+		conditions = append(conditions, query.(string))
+		bound = append(bound, args...)
+	}
+
+	q, err := ToSQL(`name: peter AND age: > 18`, &ToSQLOptions{})
+	assert.NoError(t, err)
+	clause, args := q.WhereClause()
+	where(clause, args...)
+	assert.Equal(t, []string{"name = ? AND age > ?"}, conditions)
+	assert.Equal(t, []interface{}{"peter", 18}, bound)
+
+	clause, args = Query{Query: "(a = ?) OR (b = ?)", Args: []interface{}{1, 2}}.WhereClause()
+	assert.Equal(t, "(a = ?) OR (b = ?)", clause)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	clause, args = Query{Query: " ", Args: []interface{}{}}.WhereClause()
+	assert.Equal(t, "", clause)
+	assert.Nil(t, args)
+}
+
+func TestExpandInPlaceholders(t *testing.T) {
+	// toStrings and toBytes bind the IN values as a typed slice and a single byte slice
+	toStrings := func(value interface{}) interface{} {
+		var values []string
+		for _, v := range value.([]interface{}) {
+			values = append(values, fmt.Sprintf("%v", v))
+		}
+		return values
+	}
+	toBytes := func(value interface{}) interface{} {
+		return []byte(strings.Join(toStrings(value).([]string), ","))
+	}
+	cases := []struct {
+		query string
+		opt   ToSQLOptions
+		sql   string
+		args  []interface{}
+	}{
+		{`status: ["a", "b"]`, ToSQLOptions{}, `status IN (?)`, []interface{}{[]interface{}{"a", "b"}}},
+		{`status: ["a", "b"]`, ToSQLOptions{ExpandInPlaceholders: true}, `status IN (?, ?)`, []interface{}{"a", "b"}},
+		{`status: ["a"] AND id: [1, "2"]`, ToSQLOptions{ExpandInPlaceholders: true, InHandler: InInts},
+			`(status IN (?) AND id IN (?, ?))`, []interface{}{"a", 1, 2}},
+		{`status: []`, ToSQLOptions{ExpandInPlaceholders: true}, `1 = 0`, []interface{}{}},
+		{`status: ["a", "b"]`, ToSQLOptions{ExpandInPlaceholders: true, InHandler: toStrings},
+			`status IN (?, ?)`, []interface{}{"a", "b"}},
+		{`id: [1, "2", 3]`, ToSQLOptions{ExpandInPlaceholders: true, InHandler: InInts, InChunkSize: 2},
+			`(id IN (?, ?) OR id IN (?))`, []interface{}{1, 2, 3}},
+		{`id: [1, 2]`, ToSQLOptions{ExpandInPlaceholders: true, InHandler: toBytes},
+			`id IN (?)`, []interface{}{[]byte("1,2")}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, &tc.opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+		// database/sql binds one arg per placeholder
+		assert.Equal(t, strings.Count(q.Query, PlaceHolder), len(q.Args), tc.query)
+	}
+
+	q, err := ToSQL(map[string]interface{}{"status": []string{"a", "b", "c"}}, &ToSQLOptions{ExpandInPlaceholders: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `status IN (?, ?, ?)`, q.Query)
+	assert.Equal(t, []interface{}{"a", "b", "c"}, q.Args)
+
+	q, err = ToSQL(`status: ["a", "b"] AND name: c`, &ToSQLOptions{ExpandInPlaceholders: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "(status IN ($1, $2) AND name = $3)", q.Rebind(PlaceholderDollar).Query)
+}
+
+func TestAllFieldDefault(t *testing.T) {
+	q, err := ToSQL(`_all: foo AND age: 5`, &ToSQLOptions{DefaultField: "body"})
+	assert.NoError(t, err)
+	assert.Equal(t, Query{Query: "(body = ? AND age = ?)", Args: []interface{}{"foo", 5}, Columns: []string{"age"}}, q)
+
+	_, err = ToSQL(`_all: foo`, &ToSQLOptions{})
+	assert.Error(t, err)
+}
+
+func TestEnumValues(t *testing.T) {
+	opt := &ToSQLOptions{
+		ColumnHandler: func(field interface{}) (Fragment, error) {
+			fragment := Fragment{Column: "status", Term: "status"}
+			if v, ok := field.(lucenequery.TermQuery); ok && v.Term != "status" {
+				return Fragment{Column: v.Term, Term: v.Term}, nil
+			}
+			fragment.Enum = map[string]interface{}{"open": 1, "pending": 2, "closed": 3}
+			return fragment, nil
+		},
+	}
+	cases := []struct {
+		query string
+		sql   string
+		args  []interface{}
+	}{
+		{`status: "open"`, `status = ?`, []interface{}{1}},
+		{`status: open AND name: open`, `(status = ? AND name = ?)`, []interface{}{1, "open"}},
+		{`status: ["open", "closed"]`, `status IN (?)`, []interface{}{[]interface{}{1, 3}}},
+		{`status: [open TO pending]`, `status BETWEEN ? and ?`, []interface{}{1, 2}},
+		{`status: null`, `status IS NULL`, []interface{}{}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+
+	for _, query := range []string{`status: "archived"`, `status: ["open", "archived"]`, `status: > archived`} {
+		_, err := ToSQL(query, opt)
+		assert.True(t, errors.Is(err, ErrUnknownEnum), query)
+	}
+}
+
+func TestUUIDColumns(t *testing.T) {
+	id := "0f8fad5b-d9cb-469f-a165-70867728950e"
+	opt := &ToSQLOptions{
+		ColumnHandler: func(field interface{}) (Fragment, error) {
+			if v, ok := field.(lucenequery.TermQuery); ok && v.Term != "id" {
+				return Fragment{Column: v.Term, Term: v.Term}, nil
+			}
+			return Fragment{Column: "id", Term: "id", UUID: true}, nil
+		},
+	}
+	cases := []struct {
+		query string
+		sql   string
+		args  []interface{}
+	}{
+		{`id: "` + id + `"`, `id = CAST(? AS uuid)`, []interface{}{id}},
+		{`id: != "` + id + `" AND name: "` + id + `"`, `(id <> CAST(? AS uuid) AND name = ?)`, []interface{}{id, id}},
+		{`id: ["` + id + `"]`, `id IN (?)`, []interface{}{[]interface{}{id}}},
+		{`id: ["` + id + `" TO *]`, `id >= CAST(? AS uuid)`, []interface{}{id}},
+		{`id: null`, `id IS NULL`, []interface{}{}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+
+	for _, query := range []string{`id: "not-a-uuid"`, `id: 42`, `id: ["` + id + `", "0f8fad5b"]`, `id: [abc TO *]`} {
+		_, err := ToSQL(query, opt)
+		assert.True(t, errors.Is(err, ErrInvalidUUID), query)
+	}
+}
+
+func TestNegativeNumbers(t *testing.T) {
+	cases := []struct {
+		query string
+		sql   string
+		args  []interface{}
+	}{
+		{`metric: [-1, -2, -3]`, `metric IN (?)`, []interface{}{[]interface{}{-1, -2, -3}}},
+		{`metric: [1,-2.5,3.14,-12]`, `metric IN (?)`, []interface{}{[]interface{}{1, -2.5, 3.14, -12}}},
+		{`metric: [-10 TO -5]`, `metric BETWEEN ? and ?`, []interface{}{-10, -5}},
+		{`metric: {-10.5 TO 5}`, `metric > ? and metric < ?`, []interface{}{-10.5, 5}},
+		{`metric: < -5`, `metric < ?`, []interface{}{-5}},
+		{`metric: -5`, `metric = ?`, []interface{}{-5}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, &ToSQLOptions{})
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+
+	q, err := ToSQL(`metric: [-1, -2]`, &ToSQLOptions{ExpandInPlaceholders: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "metric IN (?, ?)", q.Query)
+	assert.Equal(t, []interface{}{-1, -2}, q.Args)
+}
+
+func TestSkipEmptyStrings(t *testing.T) {
+	cases := []struct {
+		query string
+		sql   string
+		args  []interface{}
+	}{
+		{`name: ""`, ``, []interface{}{}},
+		{`name: "" AND age: 5`, `age = ?`, []interface{}{5}},
+		{`(name: "" OR email: "") AND age: 5`, `age = ?`, []interface{}{5}},
+		{`name: "" NOT age: 5`, `NOT age = ?`, []interface{}{5}},
+		{`age: 5 NOT name: ""`, `age = ?`, []interface{}{5}},
+		{`name:(+"")`, `name = ?`, []interface{}{""}},
+		{`name:(+"") AND age: 5`, `(name = ? AND age = ?)`, []interface{}{"", 5}},
+		{`name: peter AND email: ""`, `name = ?`, []interface{}{"peter"}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, &ToSQLOptions{SkipEmptyStrings: true})
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+
+	q, err := ToSQL(`name: "" AND age: 5`, &ToSQLOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, `(name = ? AND age = ?)`, q.Query)
+}
+
+func TestWholeWord(t *testing.T) {
+	opt := &ToSQLOptions{
+		ColumnHandler: func(field interface{}) (Fragment, error) {
+			name := field.(lucenequery.TermQuery).Term
+			return Fragment{Column: name, Term: name, WholeWord: name == "tags"}, nil
+		},
+	}
+	cases := []struct {
+		query string
+		sql   string
+		args  []interface{}
+	}{
+		{`tags: go`, `tags ~ ?`, []interface{}{`\ygo\y`}},
+		{`tags: "c++"`, `tags ~ ?`, []interface{}{`\yc\+\+\y`}},
+		{`tags: != go`, `tags !~ ?`, []interface{}{`\ygo\y`}},
+		{`-tags: go`, `NOT tags ~ ?`, []interface{}{`\ygo\y`}},
+		{`tags: go* AND name: go`, `(tags LIKE '?%' AND name = ?)`, []interface{}{"go", "go"}},
+		{`tags: 5`, `tags = ?`, []interface{}{5}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+}
+
+func TestWebSearch(t *testing.T) {
+	opt := &ToSQLOptions{
+		ColumnHandler: func(field interface{}) (Fragment, error) {
+			name := field.(lucenequery.TermQuery).Term
+			return Fragment{Column: name, Term: name, WebSearch: name == "body"}, nil
+		},
+	}
+	cases := []struct {
+		query string
+		sql   string
+		args  []interface{}
+	}{
+		{`body: "quick brown -fox"`, `to_tsvector(body) @@ websearch_to_tsquery(?)`, []interface{}{"quick brown -fox"}},
+		{`body: "\"quick brown\" or fox"`, `to_tsvector(body) @@ websearch_to_tsquery(?)`, []interface{}{`"quick brown" or fox`}},
+		{`body: != fox`, `NOT to_tsvector(body) @@ websearch_to_tsquery(?)`, []interface{}{"fox"}},
+		{`-body: fox`, `NOT to_tsvector(body) @@ websearch_to_tsquery(?)`, []interface{}{"fox"}},
+		{`body: fox AND title: "quick fox"`, `(to_tsvector(body) @@ websearch_to_tsquery(?) AND title = ?)`, []interface{}{"fox", "quick fox"}},
+		{`body: fox*`, `body LIKE '?%'`, []interface{}{"fox"}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+}
+
+func TestJSONPath(t *testing.T) {
+	paths := map[string]string{
+		"tags.name":        "$.tags[*].name",
+		"items.price":      "$.items[*].price",
+		"owner's.nickname": "$.owners[*] ? (@.kind == 'person').nickname",
+	}
+	opt := &ToSQLOptions{
+		ColumnHandler: func(field interface{}) (Fragment, error) {
+			var name string
+			switch f := field.(type) {
+			case lucenequery.TermQuery:
+				name = f.Term
+			case lucenequery.RangeQuery:
+				name = f.Term
+			}
+			if path, ok := paths[name]; ok {
+				return Fragment{Column: "data", Term: "data", JSONPath: path}, nil
+			}
+			return Fragment{Column: name, Term: name}, nil
+		},
+	}
+	cases := []struct {
+		query string
+		sql   string
+		args  []interface{}
+	}{
+		{`tags.name: "x"`, `jsonb_path_exists(data, ?::jsonpath, jsonb_build_object('v', ?))`, []interface{}{"$.tags[*].name ? (@ == $v)", "x"}},
+		{`items.price: > 10`, `jsonb_path_exists(data, ?::jsonpath, jsonb_build_object('min', ?))`, []interface{}{"$.items[*].price ? (@ > $min)", 10}},
+		{`items.price: <= 10`, `jsonb_path_exists(data, ?::jsonpath, jsonb_build_object('max', ?))`, []interface{}{"$.items[*].price ? (@ <= $max)", 10}},
+		{`items.price: [5 TO 10]`, `jsonb_path_exists(data, ?::jsonpath, jsonb_build_object('min', ?, 'max', ?))`, []interface{}{"$.items[*].price ? (@ >= $min && @ <= $max)", 5, 10}},
+		{`items.price: {5 TO 10}`, `jsonb_path_exists(data, ?::jsonpath, jsonb_build_object('min', ?, 'max', ?))`, []interface{}{"$.items[*].price ? (@ > $min && @ < $max)", 5, 10}},
+		{`tags.name: != x`, `NOT jsonb_path_exists(data, ?::jsonpath, jsonb_build_object('v', ?))`, []interface{}{"$.tags[*].name ? (@ == $v)", "x"}},
+		{`-tags.name: x`, `NOT jsonb_path_exists(data, ?::jsonpath, jsonb_build_object('v', ?))`, []interface{}{"$.tags[*].name ? (@ == $v)", "x"}},
+		{`"owner's.nickname": bob`, `jsonb_path_exists(data, ?::jsonpath, jsonb_build_object('v', ?))`, []interface{}{"$.owners[*] ? (@.kind == 'person').nickname ? (@ == $v)", "bob"}},
+		{`tags.name: x AND title: go`, `(jsonb_path_exists(data, ?::jsonpath, jsonb_build_object('v', ?)) AND title = ?)`, []interface{}{"$.tags[*].name ? (@ == $v)", "x", "go"}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+		assert.Equal(t, []string{"data"}, q.Columns[:1], tc.query)
+	}
+	q, err := ToSQL(`tags.name: x AND items.price: [5 TO 10] AND title: go`, opt)
+	assert.NoError(t, err)
+	assert.NoError(t, q.Validate())
+	rebound := q.Rebind(PlaceholderDollar)
+	assert.Equal(t, `(jsonb_path_exists(data, $1::jsonpath, jsonb_build_object('v', $2)) AND (jsonb_path_exists(data, $3::jsonpath, jsonb_build_object('min', $4, 'max', $5)) AND title = $6))`, rebound.Query)
+	assert.Equal(t, q.Args, rebound.Args)
+}
+
+func TestDistinctOn(t *testing.T) {
+	opt := &ToSQLOptions{
+		ColumnHandler: func(field interface{}) (Fragment, error) {
+			var name string
+			switch v := field.(type) {
+			case lucenequery.TermQuery:
+				name = v.Term
+			case lucenequery.RangeQuery:
+				name = v.Term
+			}
+			if strings.HasPrefix(name, "comments.") {
+				return Fragment{Column: name, Term: name, DistinctOn: []string{"posts.id"}}, nil
+			}
+			return Fragment{Column: name, Term: "posts." + name}, nil
+		},
+	}
+	cases := []struct {
+		query      string
+		distinctOn []string
+	}{
+		{`title: go`, nil},
+		{`title: go AND comments.author: peter`, []string{"posts.id"}},
+		{`comments.author: peter OR comments.votes: [1 TO 5]`, []string{"posts.id"}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.distinctOn, q.DistinctOn, tc.query)
+	}
+
+	g := NewGenerator(opt)
+	_, err := g.Generate(`comments.author: peter`)
+	assert.NoError(t, err)
+	q, err := g.Generate(`title: go`)
+	assert.NoError(t, err)
+	assert.Nil(t, q.DistinctOn)
+}
+
+func TestFloatEpsilon(t *testing.T) {
+	cases := []struct {
+		query string
+		opt   ToSQLOptions
+		sql   string
+		args  []interface{}
+	}{
+		{`price: eq 19.99`, ToSQLOptions{}, `price = ?`, []interface{}{19.99}},
+		{`price: eq 19.99`, ToSQLOptions{FloatEpsilon: 0.001}, `ABS(price - ?) < 0.001`, []interface{}{19.99}},
+		{`price: 19.99`, ToSQLOptions{FloatEpsilon: 1e-9}, `ABS(price - ?) < 1e-09`, []interface{}{19.99}},
+		{`price: != 19.99`, ToSQLOptions{FloatEpsilon: 0.001}, `ABS(price - ?) >= 0.001`, []interface{}{19.99}},
+		{`price: 19`, ToSQLOptions{FloatEpsilon: 0.001}, `price = ?`, []interface{}{19}},
+		{`price: > 19.99`, ToSQLOptions{FloatEpsilon: 0.001}, `price > ?`, []interface{}{19.99}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, &tc.opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+}
+
+func TestOnColumn(t *testing.T) {
+	var columns []string
+	opt := &ToSQLOptions{
+		DefaultField: "body",
+		OnColumn: func(field, op string) {
+			columns = append(columns, field+" "+op)
+		},
+	}
+	q, err := ToSQL(`name: peter AND (age: [18 TO 25] OR tags: ["a"] OR title: go*) AND deleted: null AND rust`, opt)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name =", "age BETWEEN", "tags IN", "title LIKE", "deleted IS", "body ="}, columns)
+
+	expected, err := ToSQL(`name: peter AND (age: [18 TO 25] OR tags: ["a"] OR title: go*) AND deleted: null AND rust`, &ToSQLOptions{DefaultField: "body"})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, q)
+}
+
+func TestNegationSearchModes(t *testing.T) {
+	cases := []struct {
+		query    string
+		expected map[SearchMode]string
+	}{
+		{`a: 1 -b: 2`, map[SearchMode]string{
+			SearchModeAny:        `(a = ? OR NOT b = ?)`,
+			SearchModeAll:        `(a = ? AND NOT b = ?)`,
+			SearchModeAnyExclude: `(a = ? AND NOT b = ?)`,
+		}},
+		{`a: 1 c: 3 -b: 2`, map[SearchMode]string{
+			SearchModeAny:        `(a = ? OR (c = ? OR NOT b = ?))`,
+			SearchModeAll:        `(a = ? AND (c = ? AND NOT b = ?))`,
+			SearchModeAnyExclude: `((a = ? OR c = ?) AND NOT b = ?)`,
+		}},
+		{`a: 1 OR -b: 2 OR c: 3`, map[SearchMode]string{
+			SearchModeAny:        `(a = ? OR (NOT b = ? OR c = ?))`,
+			SearchModeAll:        `(a = ? OR (NOT b = ? OR c = ?))`,
+			SearchModeAnyExclude: `((a = ? OR c = ?) AND NOT b = ?)`,
+		}},
+		{`a: 1 AND NOT b: 2`, map[SearchMode]string{
+			SearchModeAny: `(a = ? AND NOT b = ?)`,
+			SearchModeAll: `(a = ? AND NOT b = ?)`,
+		}},
+		{`a: 1 AND -b: 2`, map[SearchMode]string{
+			SearchModeAny: `(a = ? AND NOT b = ?)`,
+			SearchModeAll: `(a = ? AND NOT b = ?)`,
+		}},
+		{`a: 1 AND NOT (b: 2 OR d: 4)`, map[SearchMode]string{
+			SearchModeAny: `(a = ? AND NOT (b = ? OR d = ?))`,
+		}},
+		{`a: 1 OR NOT b: 2`, map[SearchMode]string{
+			SearchModeAny: `(a = ? OR NOT b = ?)`,
+			SearchModeAll: `(a = ? OR NOT b = ?)`,
+		}},
+		{`a: 1 NOT b: 2`, map[SearchMode]string{
+			SearchModeAny:        `(a = ? OR NOT b = ?)`,
+			SearchModeAll:        `(a = ? AND NOT b = ?)`,
+			SearchModeAnyExclude: `(a = ? AND NOT b = ?)`,
+		}},
+		{`-a: 1 -b: 2`, map[SearchMode]string{
+			SearchModeAny:        `(NOT a = ? OR NOT b = ?)`,
+			SearchModeAll:        `(NOT a = ? AND NOT b = ?)`,
+			SearchModeAnyExclude: `(NOT a = ? AND NOT b = ?)`,
+		}},
+		{`a: 1 OR c: 3 OR NOT (b: 2 OR d: 4)`, map[SearchMode]string{
+			SearchModeAnyExclude: `((a = ? OR c = ?) AND NOT (b = ? OR d = ?))`,
+		}},
+	}
+	for _, tc := range cases {
+		for mode, expected := range tc.expected {
+			q, err := ToSQL(tc.query, &ToSQLOptions{SearchMode: mode})
+			assert.NoError(t, err, tc.query)
+			assert.Equal(t, expected, q.Query, "%s [%s]", tc.query, mode)
+		}
+	}
+
+	q, err := ToSQL(`a: 1 c: 3 -b: 2`, &ToSQLOptions{SearchMode: SearchModeAnyExclude})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 3, 2}, q.Args)
+	assert.Equal(t, SearchModeAnyExclude, SearchModeAny.ValueOf("ANY_EXCLUDE"))
+}
+
+func TestScoreSQL(t *testing.T) {
+	cases := []struct {
+		query string
+		opt   ToSQLOptions
+		sql   string
+		args  []interface{}
+	}{
+		{
+			query: `title: go OR body: rust`,
+			sql:   `(CASE WHEN title = ? THEN 1 ELSE 0 END + CASE WHEN body = ? THEN 1 ELSE 0 END)`,
+			args:  []interface{}{"go", "rust"},
+		},
+		{
+			query: `title: go OR body: rust`,
+			opt:   ToSQLOptions{Boosts: map[string]float64{"title": 2.5}},
+			sql:   `(CASE WHEN title = ? THEN 2.5 ELSE 0 END + CASE WHEN body = ? THEN 1 ELSE 0 END)`,
+			args:  []interface{}{"go", "rust"},
+		},
+		{
+			query: `+title: go* AND age: [1 TO 5] -body: c NOT (tags: a)`,
+			sql:   `(CASE WHEN title LIKE '?%' THEN 1 ELSE 0 END + CASE WHEN age BETWEEN ? and ? THEN 1 ELSE 0 END)`,
+			args:  []interface{}{"go", 1, 5},
+		},
+		{
+			query: `-body: c`,
+			sql:   `0`,
+			args:  []interface{}{},
+		},
+	}
+	for _, tc := range cases {
+		q, err := ToScoreSQL(tc.query, &tc.opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+
+	_, err := ToScoreSQL(`title: (go`, &ToSQLOptions{})
+	assert.Error(t, err)
+}
+
+func TestMaxDepth(t *testing.T) {
+	// deeply nested groups are built directly as parsing them is slow
+	nested := func(n int) interface{} {
+		var node interface{} = lucenequery.TermQuery{Term: "b", Value: 2}
+		for i := 0; i < n; i++ {
+			node = lucenequery.BooleanExpression{Op: "AND", Args: []interface{}{lucenequery.TermQuery{Term: "a", Value: 1}, node}}
+		}
+		return node
+	}
+	cases := []struct {
+		name     string
+		filter   interface{}
+		maxDepth int
+		err      error
+	}{
+		{"unbounded", nested(1000), 0, nil},
+		{"at limit", nested(3), 3, nil},
+		{"over limit", nested(4), 3, ErrMaxDepthExceeded},
+		{"deeply nested", nested(100000), 100, ErrMaxDepthExceeded},
+		{"parsed at limit", `a: 1 AND (b: 2 OR c: 3)`, 2, nil},
+		{"parsed over limit", `a: 1 AND (b: 2 OR (c: 3 AND d: 4))`, 2, ErrMaxDepthExceeded},
+		{"parsed sequence", `a: 1 AND b: 2 AND c: 3 AND d: 4`, 2, ErrMaxDepthExceeded},
+	}
+	for _, tc := range cases {
+		_, err := ToSQL(tc.filter, &ToSQLOptions{MaxDepth: tc.maxDepth})
+		if tc.err == nil {
+			assert.NoError(t, err, tc.name)
+			continue
+		}
+		assert.True(t, errors.Is(err, tc.err), "%s: %v", tc.name, err)
+	}
+}
+
+func TestInChunks(t *testing.T) {
+	cases := []struct {
+		query string
+		opt   ToSQLOptions
+		sql   string
+		args  []interface{}
+	}{
+		{`id: [1, 2]`, ToSQLOptions{InChunkSize: 2}, `id IN (?)`, []interface{}{[]interface{}{1, 2}}},
+		{
+			`id: [1, 2, 3, 4, 5]`, ToSQLOptions{InChunkSize: 2},
+			`(id IN (?) OR id IN (?) OR id IN (?))`,
+			[]interface{}{[]interface{}{1, 2}, []interface{}{3, 4}, []interface{}{5}},
+		},
+		{
+			`-id: [1, 2, 3]`, ToSQLOptions{InChunkSize: 2},
+			`(id NOT IN (?) AND id NOT IN (?))`,
+			[]interface{}{[]interface{}{1, 2}, []interface{}{3}},
+		},
+		{
+			`name: peter AND -id: [1, 2, 3]`, ToSQLOptions{InChunkSize: 2, SearchMode: SearchModeAll},
+			`(name = ? AND (id NOT IN (?) AND id NOT IN (?)))`,
+			[]interface{}{"peter", []interface{}{1, 2}, []interface{}{3}},
+		},
+		{
+			`NOT id: [1, 2, 3]`, ToSQLOptions{InChunkSize: 2, InChunkJoin: "and"},
+			`(id NOT IN (?) OR id NOT IN (?))`,
+			[]interface{}{[]interface{}{1, 2}, []interface{}{3}},
+		},
+		{
+			`id: [1, 2, 3]`, ToSQLOptions{InChunkSize: 2, ExpandInPlaceholders: true},
+			`(id IN (?, ?) OR id IN (?))`,
+			[]interface{}{1, 2, 3},
+		},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, &tc.opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+}
+
+func TestColumnCase(t *testing.T) {
+	cases := []struct {
+		query string
+		opt   ToSQLOptions
+		sql   string
+		args  []interface{}
+	}{
+		{`Name: Peter AND AGE: [18 TO 25]`, ToSQLOptions{ColumnCase: ColumnCaseLower}, `(name = ? AND age BETWEEN ? and ?)`, []interface{}{"Peter", 18, 25}},
+		{`Name: Peter AND age: > 18`, ToSQLOptions{ColumnCase: ColumnCaseUpper}, `(NAME = ? AND AGE > ?)`, []interface{}{"Peter", 18}},
+		{`Name: Peter`, ToSQLOptions{}, `Name = ?`, []interface{}{"Peter"}},
+		{`Peter`, ToSQLOptions{ColumnCase: ColumnCaseUpper, DefaultField: "name"}, `NAME = ?`, []interface{}{"Peter"}},
+		{`Labels.Env: Prod`, ToSQLOptions{ColumnCase: ColumnCaseLower, JSONColumns: []string{"Labels"}}, `labels->>'Env' = ?`, []interface{}{"Prod"}},
+		{`Author: peter`, ToSQLOptions{ColumnCase: ColumnCaseLower, FieldAliases: map[string]string{"Author": "Created_By"}}, `created_by = ?`, []interface{}{"peter"}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, &tc.opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+
+	assert.Equal(t, `"Data"->>'Key'`, ColumnCaseUpper.Apply(`"Data"->>'Key'`))
+	assert.Equal(t, `DATA->>'Key'`, ColumnCaseUpper.Apply(`data->>'Key'`))
+	assert.Equal(t, ColumnCaseLower, ColumnCaseDefault.ValueOf("LOWER"))
+}
+
+func TestSoftDelete(t *testing.T) {
+	cases := []struct {
+		query string
+		sql   string
+		args  []interface{}
+	}{
+		{`name: peter`, `name = ? AND deleted_at IS NULL`, []interface{}{"peter"}},
+		{`name: peter AND age: > 18`, `(name = ? AND age > ?) AND deleted_at IS NULL`, []interface{}{"peter", 18}},
+		{`name: peter OR name: paul`, `(name = ? OR name = ?) AND deleted_at IS NULL`, []interface{}{"peter", "paul"}},
+		{`age: [18 TO 30]`, `age BETWEEN ? and ? AND deleted_at IS NULL`, []interface{}{18, 30}},
+		{`deleted_at: *`, `deleted_at IS NOT NULL`, []interface{}{}},
+		{`name: peter AND deleted_at: > "2021-01-01"`, `(name = ? AND deleted_at > ?)`, []interface{}{"peter", "2021-01-01"}},
+	}
+	opt := &ToSQLOptions{SoftDelete: SoftDelete{Column: "deleted_at"}}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+		assert.NoError(t, q.Validate(), tc.query)
+	}
+
+	q, err := ToSQL(map[string]interface{}{}, opt)
+	assert.NoError(t, err)
+	assert.Equal(t, `deleted_at IS NULL`, q.Query)
+
+	q, err = ToSQL(`name: peter`, &ToSQLOptions{
+		SoftDelete: SoftDelete{Column: "deleted_at"},
+		ColumnCase: ColumnCaseUpper,
+		WrapResult: WrapResultAlways,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `(NAME = ? AND DELETED_AT IS NULL)`, q.Query)
+
+	q, err = ToSQL(`name: peter`, &ToSQLOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, `name = ?`, q.Query)
+}
+
+func TestCollation(t *testing.T) {
+	opt := &ToSQLOptions{
+		ColumnHandler: func(field interface{}) (Fragment, error) {
+			name := field.(lucenequery.TermQuery).Term
+			if name == "email" {
+				return Fragment{Column: name, Term: name, Collation: "und-u-ks-level2"}, nil
+			}
+			return Fragment{Column: name, Term: name}, nil
+		},
+	}
+	cases := []struct {
+		query string
+		sql   string
+		args  []interface{}
+	}{
+		{`email: "Peter@Example.com"`, `email = ? COLLATE "und-u-ks-level2"`, []interface{}{"Peter@Example.com"}},
+		{`email: != "Peter@Example.com"`, `email <> ? COLLATE "und-u-ks-level2"`, []interface{}{"Peter@Example.com"}},
+		{`email: peter AND name: Peter`, `(email = ? COLLATE "und-u-ks-level2" AND name = ?)`, []interface{}{"peter", "Peter"}},
+		{`email: 5`, `email = ?`, []interface{}{5}},
+		{`email: peter*`, `email LIKE '?%'`, []interface{}{"peter"}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, opt)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+
+	q, err := ToSQL(`email: peter`, &ToSQLOptions{
+		ColumnHandler: func(field interface{}) (Fragment, error) {
+			return Fragment{Column: "email", Term: "email", Collation: `my"collation`}, nil
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `email = ? COLLATE "my""collation"`, q.Query)
+}
+
+func TestRenderHook(t *testing.T) {
+	var rendered Query
+	opt := &ToSQLOptions{
+		RenderHook: func(query Query) (Query, error) {
+			rendered = query
+			query.Query = fmt.Sprintf("%s AND tenant_id = 42", query.Query)
+			return query, nil
+		},
+		WrapResult: WrapResultAlways,
+	}
+	q, err := ToSQL(`name: peter OR age: > 18`, opt)
+	assert.NoError(t, err)
+	assert.Equal(t, `(name = ? OR age > ?)`, rendered.Query)
+	assert.Equal(t, `(name = ? OR age > ?) AND tenant_id = 42`, q.Query)
+	assert.Equal(t, []interface{}{"peter", 18}, q.Args)
+	assert.Equal(t, []string{"name", "age"}, q.Columns)
+	assert.NoError(t, q.Validate())
+
+	errHook := errors.New("hook failed")
+	_, err = ToSQL(`name: peter`, &ToSQLOptions{RenderHook: func(query Query) (Query, error) {
+		return query, errHook
+	}})
+	assert.True(t, errors.Is(err, errHook))
+
+	_, err = ToSQL(`name: peter`, &ToSQLOptions{
+		MaxQueryLength: 20,
+		RenderHook: func(query Query) (Query, error) {
+			query.Query += " AND tenant_id = 42"
+			return query, nil
+		},
+	})
+	assert.True(t, errors.Is(err, ErrQueryTooLong))
+}
+
+func TestGroupedComparisons(t *testing.T) {
+	cases := []struct {
+		query string
+		sql   string
+		args  []interface{}
+	}{
+		{`price:(> 10 < 100)`, `price > ? and price < ?`, []interface{}{10, 100}},
+		{`price:(>= 10 <= 100)`, `price BETWEEN ? and ?`, []interface{}{10, 100}},
+		{`price:(>= 10 < 100)`, `(price >= ? AND price < ?)`, []interface{}{10, 100}},
+		{`price:(> 10 OR < 5)`, `(price > ? OR price < ?)`, []interface{}{10, 5}},
+		{`name: peter AND price:(> 10 < 100)`, `(name = ? AND price > ? and price < ?)`, []interface{}{"peter", 10, 100}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, &ToSQLOptions{})
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+}
+
+func TestClickHouseDialect(t *testing.T) {
+	cases := []struct {
+		query string
+		sql   string
+		args  []interface{}
+	}{
+		{`path: ~ "^/api/"`, `match(path, ?)`, []interface{}{"^/api/"}},
+		{`path: ~* "^/API/"`, `match(path, ?)`, []interface{}{"(?i)^/API/"}},
+		{`path: !~ "^/api/"`, `NOT match(path, ?)`, []interface{}{"^/api/"}},
+		{`path: !~* "^/api/"`, `NOT match(path, ?)`, []interface{}{"(?i)^/api/"}},
+		{`tags: all ["go", "sql"]`, `hasAll(tags, ?)`, []interface{}{[]interface{}{"go", "sql"}}},
+		{`tags: any ["go", "sql"]`, `hasAny(tags, ?)`, []interface{}{[]interface{}{"go", "sql"}}},
+		{`tags: any ["go"]`, `has(tags, ?)`, []interface{}{"go"}},
+		{`tags: all []`, `1 = 1`, []interface{}{}},
+		{`status: [200 TO 299]`, `status BETWEEN ? and ?`, []interface{}{200, 299}},
+		{`level: error AND path: ~ "^/api/"`, `(level = ? AND match(path, ?))`, []interface{}{"error", "^/api/"}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, &ToSQLOptions{Dialect: DialectClickHouse})
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+
+	q, err := ToSQL(`message: timeout`, &ToSQLOptions{
+		Dialect: DialectClickHouse,
+		ColumnHandler: func(field interface{}) (Fragment, error) {
+			return Fragment{Column: "message", Term: "message", WholeWord: true}, nil
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `match(message, ?)`, q.Query)
+	assert.Equal(t, []interface{}{`\btimeout\b`}, q.Args)
+
+	assert.Equal(t, DialectClickHouse, DialectStandard.ValueOf("CLICKHOUSE"))
+	assert.Equal(t, "CLICKHOUSE", DialectClickHouse.String())
+}
+
+func TestOracleDialect(t *testing.T) {
+	query, err := ToSQL(`name: peter AND (age: [18 TO 25] OR status: ["a", "b"])`, &ToSQLOptions{
+		Dialect:              DialectOracle,
+		ExpandInPlaceholders: true,
+	})
+	assert.NoError(t, err)
+	rebound := query.Rebind(PlaceholderColon)
+	assert.Equal(t, `(name = :1 AND (age BETWEEN :2 and :3 OR status IN (:4, :5)))`, rebound.Query)
+	assert.Equal(t, []interface{}{"peter", 18, 25, "a", "b"}, rebound.Args)
+	assert.Equal(t, PlaceholderColon, PlaceholderQuestion.ValueOf("COLON"))
+
+	cases := []struct {
+		query string
+		sql   string
+		args  []interface{}
+	}{
+		{`name: ~ "^pe"`, `REGEXP_LIKE(name, ?)`, []interface{}{"^pe"}},
+		{`name: ~* "^PE"`, `REGEXP_LIKE(name, ?, 'i')`, []interface{}{"^PE"}},
+		{`name: !~ "^pe"`, `NOT REGEXP_LIKE(name, ?)`, []interface{}{"^pe"}},
+		{`name: !~* "^pe"`, `NOT REGEXP_LIKE(name, ?, 'i')`, []interface{}{"^pe"}},
+		{`active: true`, `active = ?`, []interface{}{true}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, &ToSQLOptions{Dialect: DialectOracle})
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+	}
+
+	q, err := ToSQL(`name: ~ "^pe" AND age: 5`, &ToSQLOptions{Dialect: DialectOracle})
+	assert.NoError(t, err)
+	assert.Equal(t, `(REGEXP_LIKE(name, :1) AND age = :2)`, q.Rebind(PlaceholderColon).Query)
+
+	q, err = ToSQL(`active: true`, &ToSQLOptions{Dialect: DialectOracle, InlineBooleans: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `active = 1`, q.Query)
+
+	_, err = ToSQL(`tags: all ["go"]`, &ToSQLOptions{Dialect: DialectOracle})
+	assert.True(t, errors.Is(err, ErrUnsupportedOperator))
+	_, err = ToSQL(`tags: go`, &ToSQLOptions{
+		Dialect: DialectOracle,
+		ColumnHandler: func(field interface{}) (Fragment, error) {
+			return Fragment{Column: "tags", Term: "tags", WholeWord: true}, nil
+		},
+	})
+	assert.True(t, errors.Is(err, ErrUnsupportedOperator))
+}
+
+func TestNegatedWildcards(t *testing.T) {
+	cases := []struct {
+		query string
+		mode  SearchMode
+		sql   string
+		args  []interface{}
+	}{
+		{`name: -foo*`, SearchModeAny, `name NOT LIKE '?%'`, []interface{}{"foo"}},
+		{`-name: foo*`, SearchModeAll, `name NOT LIKE '?%'`, []interface{}{"foo"}},
+		{`name: -*foo*`, SearchModeAny, `name NOT LIKE '%?%'`, []interface{}{"foo"}},
+		{`name: -foo*bar`, SearchModeAny, `name NOT LIKE '?%?'`, []interface{}{"foo", "bar"}},
+		{`name: -*`, SearchModeAny, `name IS NULL`, []interface{}{}},
+		{`status: open -name: foo*`, SearchModeAny, `(status = ? OR name NOT LIKE '?%')`, []interface{}{"open", "foo"}},
+		{`status: open -name: foo*`, SearchModeAll, `(status = ? AND name NOT LIKE '?%')`, []interface{}{"open", "foo"}},
+		{`-name: foo* AND status: open`, SearchModeAll, `(name NOT LIKE '?%' AND status = ?)`, []interface{}{"foo", "open"}},
+		{`NOT name: foo*`, SearchModeAll, `name NOT LIKE '?%'`, []interface{}{"foo"}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.query, &ToSQLOptions{SearchMode: tc.mode})
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.sql, q.Query, tc.query)
+		assert.Equal(t, tc.args, q.Args, tc.query)
+		assert.NoError(t, q.Validate(), tc.query)
+	}
+
+	// ranges rendered for prefixes are negated as a whole
+	q, err := ToSQL(`name: -foo*`, &ToSQLOptions{PrefixAsRange: true})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(q.Query, "NOT "), q.Query)
+}
+
+func TestFlatten(t *testing.T) {
+	status := lucenequery.TermQuery{Term: "status", Value: "open"}
+	cases := []struct {
+		filter    interface{}
+		sql       string
+		flattened string
+	}{
+		{
+			lucenequery.BooleanExpression{Op: "AND", Args: []interface{}{
+				lucenequery.BooleanExpression{Op: "OR", Args: []interface{}{status}},
+			}},
+			`((status = ?))`,
+			`status = ?`,
+		},
+		{
+			lucenequery.BooleanExpression{Op: "AND", Args: []interface{}{
+				lucenequery.BooleanExpression{Op: "OR", Args: []interface{}{status}},
+				lucenequery.TermQuery{Term: "name", Value: "peter"},
+			}},
+			`((status = ?) AND name = ?)`,
+			`(status = ? AND name = ?)`,
+		},
+		{`((status:open))`, `status = ?`, `status = ?`},
+		{`NOT ((status:open))`, `NOT status = ?`, `NOT status = ?`},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.filter, &ToSQLOptions{})
+		assert.NoError(t, err, tc.filter)
+		assert.Equal(t, tc.sql, q.Query, tc.filter)
+
+		flattened, err := ToSQL(tc.filter, &ToSQLOptions{Flatten: true})
+		assert.NoError(t, err, tc.filter)
+		assert.Equal(t, tc.flattened, flattened.Query, tc.filter)
+		assert.Equal(t, q.Args, flattened.Args, tc.filter)
+	}
+}
+
+func TestRangeContainment(t *testing.T) {
+	opt := &ToSQLOptions{
+		ColumnHandler: func(v interface{}) (Fragment, error) {
+			term := v.(lucenequery.TermQuery).Term
+			ranges := map[string]string{"period": "daterange", "during": "tstzrange", "span": "ranges.span"}
+			return Fragment{Column: term, Term: term, Range: ranges[term]}, nil
+		},
+		OnUnknownOperator: OperatorPolicyError,
+	}
+	cases := []struct {
+		filter string
+		sql    string
+		args   []interface{}
+	}{
+		{`period: contains "2020-06-01"`, `period @> CAST(? AS date)`, []interface{}{"2020-06-01"}},
+		{`-period: contains "2020-06-01"`, `NOT period @> CAST(? AS date)`, []interface{}{"2020-06-01"}},
+		{`during: contains "2020-06-01T10:00:00Z" AND name: peter`, `(during @> CAST(? AS timestamptz) AND name = ?)`, []interface{}{"2020-06-01T10:00:00Z", "peter"}},
+		{`span: contains 5`, `span @> ?`, []interface{}{5}},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.filter, opt)
+		assert.NoError(t, err, tc.filter)
+		assert.Equal(t, tc.sql, q.Query, tc.filter)
+		assert.Equal(t, tc.args, q.Args, tc.filter)
+	}
+
+	for _, filter := range []string{`name: contains "pete"`, `title: contains foo`, `period: contains "2020-06-01" OR title: contains foo`} {
+		for _, policy := range []OperatorPolicy{OperatorPolicyFallback, OperatorPolicyError} {
+			_, err := ToSQL(filter, &ToSQLOptions{ColumnHandler: opt.ColumnHandler, OnUnknownOperator: policy})
+			assert.True(t, errors.Is(err, ErrUnsupportedOperator), err)
+		}
+	}
+
+	q, err := ToSQL(`name: contains status: open`, opt)
+	assert.NoError(t, err)
+	assert.Equal(t, `(name = ? OR status = ?)`, q.Query)
+	assert.Equal(t, []interface{}{"contains", "open"}, q.Args)
+}
+
+func TestFieldCasts(t *testing.T) {
+	cases := []struct {
+		filter string
+		sql    string
+		args   []interface{}
+	}{
+		{`id::int: "5"`, `id = CAST(? AS int)`, []interface{}{"5"}},
+		{`-id::int: "5"`, `NOT id = CAST(? AS int)`, []interface{}{"5"}},
+		{`created::date: ["2020-01-01" TO "2020-02-01"]`, `created BETWEEN CAST(? AS date) and CAST(? AS date)`, []interface{}{"2020-01-01", "2020-02-01"}},
+		{`data: "{}" AND id::int: > "5"`, `(data = CAST(? AS jsonb) AND id > CAST(? AS int))`, []interface{}{"{}", "5"}},
+		{`data::text: "{}"`, `data = CAST(? AS jsonb)`, []interface{}{"{}"}},
+	}
+	opt := &ToSQLOptions{
+		ColumnHandler: func(v interface{}) (Fragment, error) {
+			var term string
+			switch f := v.(type) {
+			case lucenequery.TermQuery:
+				term = f.Term
+			case lucenequery.RangeQuery:
+				term = f.Term
+			}
+			if term == "data" {
+				return Fragment{Column: term, Term: term, Cast: "jsonb"}, nil
+			}
+			return Fragment{Column: term, Term: term}, nil
+		},
+	}
+	for _, tc := range cases {
+		q, err := ToSQL(tc.filter, opt)
+		assert.NoError(t, err, tc.filter)
+		assert.Equal(t, tc.sql, q.Query, tc.filter)
+		assert.Equal(t, tc.args, q.Args, tc.filter)
+	}
+
+	_, err := ToSQL(`id::int4: "5"`, opt)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid cast: `int4` is not a supported type")
+
+	_, err = ToSQL(lucenequery.TermQuery{Term: "id", Value: "5", Cast: "int); DROP TABLE users; --"}, opt)
+	assert.True(t, errors.Is(err, lucenequery.ErrInvalidCast), err)
+}
+
+func TestPrefixAsRange(t *testing.T) {
+	cases := []struct {
+		filter string
+		sql    string
+		args   []interface{}
+	}{
+		{
+			filter: `name: abc*`,
+			sql:    `(name >= ? AND name < ?)`,
+			args:   []interface{}{"abc", "abd"},
+		},
+		{
+			filter: `age: 5 name: -abc*`,
+			sql:    `(age = ? OR NOT (name >= ? AND name < ?))`,
+			args:   []interface{}{5, "abc", "abd"},
+		},
+		{
+			filter: "name: \"ab\U0010FFFF\"*",
+			sql:    `(name >= ? AND name < ?)`,
+			args:   []interface{}{"ab\U0010FFFF", "ac"},
+		},
+		{
+			filter: "name: \"\U0010FFFF\"*",
+			sql:    `name >= ?`,
+			args:   []interface{}{"\U0010FFFF"},
+		},
+		{
+			filter: "name: \"a\uD7FF\"*",
+			sql:    `(name >= ? AND name < ?)`,
+			args:   []interface{}{"a\uD7FF", "a\uE000"},
+		},
+		{
+			filter: `name: *abc`,
+			sql:    `name LIKE '%?'`,
+			args:   []interface{}{"abc"},
+		},
+	}
+	for _, dt := range cases {
+		query, err := ToSQL(dt.filter, &ToSQLOptions{PrefixAsRange: true})
+		assert.NoError(t, err, dt)
+		assert.Equal(t, dt.sql, query.Query, dt)
+		assert.Equal(t, dt.args, query.Args, dt)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	cases := []struct {
+		filter  string
+		sql     string
+		args    []interface{}
+		groupBy []string
+	}{
+		{
+			filter:  `status: open groupby: category`,
+			sql:     `status = ?`,
+			args:    []interface{}{"open"},
+			groupBy: []string{"category"},
+		},
+		{
+			filter:  `group: category AND status: open AND group: owner`,
+			sql:     `status = ?`,
+			args:    []interface{}{"open"},
+			groupBy: []string{"category", "owner_id"},
+		},
+		{
+			filter:  `groupby: ["category", "owner"] (status: open OR age: > 5)`,
+			sql:     `(status = ? OR age > ?)`,
+			args:    []interface{}{"open", 5},
+			groupBy: []string{"category", "owner_id"},
+		},
+		{
+			filter: `status: open`,
+			sql:    `status = ?`,
+			args:   []interface{}{"open"},
+		},
+		{
+			filter:  `groupby: category`,
+			sql:     ``,
+			args:    []interface{}{},
+			groupBy: []string{"category"},
+		},
+		{
+			filter:  `group: category AND group: owner`,
+			sql:     ``,
+			args:    []interface{}{},
+			groupBy: []string{"category", "owner_id"},
+		},
+	}
+	for _, dt := range cases {
+		query, err := ToSQL(dt.filter, &ToSQLOptions{GroupBy: true, FieldAliases: map[string]string{"owner": "owner_id"}})
+		assert.NoError(t, err, dt)
+		assert.Equal(t, dt.sql, query.Query, dt)
+		assert.Equal(t, dt.args, query.Args, dt)
+		assert.Equal(t, dt.groupBy, query.GroupBy, dt)
+	}
+
+	query, err := ToSQL(`group: category`, &ToSQLOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, `group = ?`, query.Query)
+	assert.Nil(t, query.GroupBy)
+
+	_, err = ToSQL(`group: 5`, &ToSQLOptions{GroupBy: true})
+	assert.Error(t, err)
+}
+
+func TestDistinctColumns(t *testing.T) {
+	cases := []struct {
+		filter   string
+		sql      string
+		args     []interface{}
+		distinct string
+		dargs    []interface{}
+	}{
+		{
+			filter:   `status:open status:open`,
+			sql:      `(status = ? OR status = ?)`,
+			args:     []interface{}{"open", "open"},
+			distinct: `(status = ?)`,
+			dargs:    []interface{}{"open"},
+		},
+		{
+			filter:   `status:open AND (age:5 OR age:5) AND status:open`,
+			sql:      `(status = ? AND ((age = ? OR age = ?) AND status = ?))`,
+			args:     []interface{}{"open", 5, 5, "open"},
+			distinct: `(status = ? AND (age = ?))`,
+			dargs:    []interface{}{"open", 5},
+		},
+		{
+			filter:   `status:open status:closed status:[1,2] status:[1,2]`,
+			sql:      `(status = ? OR (status = ? OR (status IN (?) OR status IN (?))))`,
+			args:     []interface{}{"open", "closed", []interface{}{1, 2}, []interface{}{1, 2}},
+			distinct: `(status = ? OR status = ? OR status IN (?))`,
+			dargs:    []interface{}{"open", "closed", []interface{}{1, 2}},
+		},
+		{
+			filter:   `status:open status:closed status:open`,
+			sql:      `(status = ? OR (status = ? OR status = ?))`,
+			args:     []interface{}{"open", "closed", "open"},
+			distinct: `(status = ? OR status = ?)`,
+			dargs:    []interface{}{"open", "closed"},
+		},
+		{
+			filter:   `status:open NOT status:open`,
+			sql:      `(status = ? OR NOT status = ?)`,
+			args:     []interface{}{"open", "open"},
+			distinct: `(status = ? OR NOT status = ?)`,
+			dargs:    []interface{}{"open", "open"},
+		},
+	}
+	for _, dt := range cases {
+		query, err := ToSQL(dt.filter, &ToSQLOptions{})
+		assert.NoError(t, err, dt)
+		assert.Equal(t, dt.sql, query.Query, dt)
+		assert.Equal(t, dt.args, query.Args, dt)
+
+		var indexes []int
+		query, err = ToSQL(dt.filter, &ToSQLOptions{
+			DistinctColumns: true,
+			BindHook: func(index int, op string, value interface{}) (interface{}, error) {
+				indexes = append(indexes, index)
+				return value, nil
+			},
+		})
+		assert.NoError(t, err, dt)
+		assert.Equal(t, dt.distinct, query.Query, dt)
+		assert.Equal(t, dt.dargs, query.Args, dt)
+		assert.Equal(t, len(dt.args), len(indexes), dt)
+	}
+}
@@ -0,0 +1,53 @@
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidCursor is returned when the values of a keyset cursor do not match the sort keys
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// SortKey is a column of the order rows are paginated in
+type SortKey struct {
+	// Column is the column the rows are sorted by
+	Column string
+	// Desc sorts the column in descending order
+	Desc bool
+}
+
+// Keyset returns the keyset pagination predicate selecting the rows after the cursor, which holds
+// the values of the sort keys of the last row of the previous page e.g. for `created DESC, id DESC`
+// the predicate is `(created < ? OR (created = ? AND id < ?))`. The last key should be unique
+func Keyset(keys []SortKey, cursor []interface{}) (Query, error) {
+	query := Query{Query: "", Args: []interface{}{}, Columns: []string{}}
+	if len(keys) == 0 || len(keys) != len(cursor) {
+		return query, fmt.Errorf("%w: %d values for %d sort keys", ErrInvalidCursor, len(cursor), len(keys))
+	}
+	var clauses []string
+	for i, key := range keys {
+		var conditions []string
+		for j, previous := range keys[:i] {
+			conditions = append(conditions, fmt.Sprintf("%s = %s", previous.Column, PlaceHolder))
+			query.Args = append(query.Args, cursor[j])
+		}
+		op := ">"
+		if key.Desc {
+			op = "<"
+		}
+		conditions = append(conditions, fmt.Sprintf("%s %s %s", key.Column, op, PlaceHolder))
+		query.Args = append(query.Args, cursor[i])
+		query.Columns = appendColumns(query.Columns, key.Column)
+		if len(conditions) == 1 {
+			clauses = append(clauses, conditions[0])
+			continue
+		}
+		clauses = append(clauses, fmt.Sprintf("(%s)", strings.Join(conditions, " AND ")))
+	}
+	query.Query = strings.Join(clauses, " OR ")
+	if len(clauses) > 1 {
+		query.Query = fmt.Sprintf("(%s)", query.Query)
+	}
+	return query, nil
+}
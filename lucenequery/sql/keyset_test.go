@@ -0,0 +1,49 @@
+package sql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyset(t *testing.T) {
+	cases := []struct {
+		keys   []SortKey
+		cursor []interface{}
+		sql    string
+		args   []interface{}
+	}{
+		{[]SortKey{{Column: "id"}}, []interface{}{5}, `id > ?`, []interface{}{5}},
+		{[]SortKey{{Column: "created", Desc: true}}, []interface{}{"2021-03-01"}, `created < ?`, []interface{}{"2021-03-01"}},
+		{
+			[]SortKey{{Column: "created", Desc: true}, {Column: "id", Desc: true}},
+			[]interface{}{"2021-03-01", 5},
+			`(created < ? OR (created = ? AND id < ?))`,
+			[]interface{}{"2021-03-01", "2021-03-01", 5},
+		},
+		{
+			[]SortKey{{Column: "name"}, {Column: "created", Desc: true}, {Column: "id"}},
+			[]interface{}{"peter", "2021-03-01", 5},
+			`(name > ? OR (name = ? AND created < ?) OR (name = ? AND created = ? AND id > ?))`,
+			[]interface{}{"peter", "peter", "2021-03-01", "peter", "2021-03-01", 5},
+		},
+	}
+	for _, tc := range cases {
+		q, err := Keyset(tc.keys, tc.cursor)
+		assert.NoError(t, err, tc.sql)
+		assert.Equal(t, tc.sql, q.Query)
+		assert.Equal(t, tc.args, q.Args, tc.sql)
+		assert.NoError(t, q.Validate(), tc.sql)
+	}
+
+	q, err := Keyset([]SortKey{{Column: "created", Desc: true}, {Column: "id", Desc: true}}, []interface{}{"2021-03-01", 5})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"created", "id"}, q.Columns)
+	assert.Equal(t, `(created < $1 OR (created = $2 AND id < $3))`, q.Rebind(PlaceholderDollar).Query)
+
+	for _, cursor := range [][]interface{}{nil, {1}, {1, 2, 3}} {
+		_, err := Keyset([]SortKey{{Column: "created"}, {Column: "id"}}, cursor)
+		assert.True(t, errors.Is(err, ErrInvalidCursor), cursor)
+	}
+}
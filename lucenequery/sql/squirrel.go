@@ -0,0 +1,34 @@
+package sql
+
+import "fmt"
+
+// Sqlizer has the method set of the squirrel `Sqlizer` interface, the queries returned by ToSquirrel
+// can be used as squirrel conditions e.g. `sq.And{filter, sq.Eq{"deleted": false}}` without this
+// package depending on squirrel
+type Sqlizer interface {
+	ToSql() (string, []interface{}, error)
+}
+
+// ToSql returns the predicate and args of the query, implementing the squirrel Sqlizer interface.
+// The predicate uses `?` bind variables which squirrel replaces with the PlaceholderFormat of the
+// statement. A predicate of several clauses is parenthesized, as squirrel conjunctions join their
+// conditions as is e.g. `sq.And{q, sq.Eq{"deleted": false}}` is `((a = ? OR b = ?) AND deleted = ?)`.
+// An empty query returns an empty predicate, which squirrel conjunctions skip
+func (q Query) ToSql() (string, []interface{}, error) {
+	clause, args := q.WhereClause()
+	if multiple(clause) {
+		clause = fmt.Sprintf("(%s)", clause)
+	}
+	return clause, args, nil
+}
+
+// ToSquirrel returns the query of the filter as a squirrel condition, composing with other squirrel
+// conditions instead of splicing the generated SQL into a statement e.g.
+// `sq.Select("*").From("users").Where(filter).PlaceholderFormat(sq.Dollar)`
+func ToSquirrel(filter interface{}, opt *ToSQLOptions) (Sqlizer, error) {
+	query, err := ToSQL(filter, opt)
+	if err != nil {
+		return nil, err
+	}
+	return query, nil
+}
@@ -0,0 +1,80 @@
+package sql
+
+import (
+	"fmt"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSquirrel(t *testing.T) {
+	cases := []struct {
+		filter interface{}
+		opt    *ToSQLOptions
+		sql    string
+		args   []interface{}
+	}{
+		{`name: peter`, &ToSQLOptions{}, `name = ?`, []interface{}{"peter"}},
+		{`name: peter OR role: admin`, &ToSQLOptions{}, `(name = ? OR role = ?)`, []interface{}{"peter", "admin"}},
+		{
+			`name: peter AND (age: > 18 OR role: admin)`, &ToSQLOptions{},
+			`(name = ? AND (age > ? OR role = ?))`, []interface{}{"peter", 18, "admin"},
+		},
+		{`(name: peter) (role: admin)`, &ToSQLOptions{}, `(name = ? OR role = ?)`, []interface{}{"peter", "admin"}},
+		{`age: [18 TO 30]`, &ToSQLOptions{}, `age BETWEEN ? and ?`, []interface{}{18, 30}},
+		{`-name: peter`, &ToSQLOptions{}, `NOT name = ?`, []interface{}{"peter"}},
+		{`name: "a OR b"`, &ToSQLOptions{}, `name = ?`, []interface{}{"a OR b"}},
+		{
+			`name: peter`, &ToSQLOptions{SoftDelete: SoftDelete{Column: "deleted_at"}},
+			`(name = ? AND deleted_at IS NULL)`, []interface{}{"peter"},
+		},
+	}
+	for _, tc := range cases {
+		filter, err := ToSquirrel(tc.filter, tc.opt)
+		assert.NoError(t, err, tc.filter)
+		clause, args, err := filter.ToSql()
+		assert.NoError(t, err, tc.filter)
+		assert.Equal(t, tc.sql, clause, tc.filter)
+		assert.Equal(t, tc.args, args, tc.filter)
+	}
+
+	clause, args, err := Query{}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "", clause)
+	assert.Nil(t, args)
+
+	_, err = ToSquirrel(`name: peter`, &ToSQLOptions{ColumnHandler: func(interface{}) (Fragment, error) {
+		return Fragment{}, fmt.Errorf("unknown field")
+	}})
+	assert.Error(t, err)
+}
+
+func TestSquirrelConjunctions(t *testing.T) {
+	filter, err := ToSquirrel(`name: peter OR (age: > 18 AND role: admin)`, &ToSQLOptions{})
+	assert.NoError(t, err)
+	other, err := ToSquirrel(`status: open`, &ToSQLOptions{})
+	assert.NoError(t, err)
+
+	clause, args, err := sq.And{filter, sq.Eq{"deleted": false}}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `((name = ? OR (age > ? AND role = ?)) AND deleted = ?)`, clause)
+	assert.Equal(t, []interface{}{"peter", 18, "admin", false}, args)
+
+	clause, args, err = sq.Or{sq.And{filter, sq.Eq{"deleted": false}}, other}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `(((name = ? OR (age > ? AND role = ?)) AND deleted = ?) OR status = ?)`, clause)
+	assert.Equal(t, []interface{}{"peter", 18, "admin", false, "open"}, args)
+
+	clause, args, err = sq.Select("*").From("users").
+		Where(sq.And{filter, sq.Eq{"deleted": false}}).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT * FROM users WHERE ((name = $1 OR (age > $2 AND role = $3)) AND deleted = $4)`, clause)
+	assert.Equal(t, []interface{}{"peter", 18, "admin", false}, args)
+
+	clause, args, err = sq.And{Query{}, sq.Eq{"deleted": false}}.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, `(deleted = ?)`, clause)
+	assert.Equal(t, []interface{}{false}, args)
+}
@@ -0,0 +1,355 @@
+package lucenequery
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// reservedWords are the words that are parsed as operators or literals when unquoted
+var reservedWords = map[string]bool{
+	"and": true, "or": true, "not": true, "to": true,
+	"true": true, "false": true, "today": true, "yesterday": true, "now": true,
+	"all": true, "any": true, "eq": true, "neq": true, "gt": true, "gte": true, "lt": true, "lte": true,
+	"contains": true,
+}
+
+// equalitySymbols are the comparators written before the value of a term
+var equalitySymbols = map[string]string{
+	"eq":  "eq",
+	"neq": "!=",
+	"gt":  ">",
+	"gte": ">=",
+	"lt":  "<",
+	"lte": "<=",
+}
+
+// Format returns the query string of the node, parsing the string returns a node equal to the node.
+// Nested boolean expressions are parenthesized and strings are quoted when needed
+func Format(node interface{}) string {
+	switch v := node.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		var nodes []string
+		for _, n := range v {
+			nodes = append(nodes, Format(n))
+		}
+		return strings.Join(nodes, " ")
+	case fmt.Stringer:
+		return v.String()
+	}
+	return formatValue(node)
+}
+
+// String returns the query string of the expression
+func (e BooleanExpression) String() string {
+	if len(e.Args) == 0 {
+		return e.Op
+	}
+	if e.Op == "NOT" && len(e.Args) == 1 {
+		return "NOT " + formatArg(e.Args[0])
+	}
+	op := " " + e.Op + " "
+	if e.Op == "IMPLICIT" {
+		op = " "
+	}
+	var args []string
+	for _, arg := range e.Args {
+		args = append(args, formatArg(arg))
+	}
+	return strings.Join(args, op)
+}
+
+// String returns the query string of the term e.g. `-name: "peter pan"`
+func (t TermQuery) String() string {
+	var op string
+	switch t.Op {
+	case "", "in":
+	default:
+		op = t.Op + " "
+		if symbol, ok := equalitySymbols[t.Op]; ok {
+			op = symbol + " "
+		}
+	}
+	value := formatValue(t.Value)
+	if t.Term != "" {
+		return t.Prefix + formatField(t.Term) + formatCast(t.Cast) + ": " + op + value
+	}
+	if t.Prefix != "-" {
+		return op + t.Prefix + value
+	}
+	switch t.Value.(type) {
+	case int, float64:
+		// a - before a number is its sign
+	default:
+		if op == "" {
+			return "-" + value
+		}
+	}
+	return "NOT " + op + value
+}
+
+// String returns the query string of the range e.g. `age: [18 TO *]`
+func (q RangeQuery) String() string {
+	open, close := "{", "}"
+	if q.Inclusive {
+		open, close = "[", "]"
+	}
+	query := open + formatBound(q.Min) + " TO " + formatBound(q.Max) + close
+	if q.Term == "" {
+		return query
+	}
+	return formatField(q.Term) + formatCast(q.Cast) + ": " + query
+}
+
+// String returns the pattern of the wildcard e.g. `gopher*`
+func (q WildCardQuery) String() string {
+	switch q.Kind() {
+	case "any":
+		return "*" + formatString(q.Term) + "*"
+	case "prefix":
+		return formatString(q.Prefix) + "*"
+	case "suffix":
+		return "*" + formatString(q.Suffix)
+	case "between":
+		return formatString(q.Prefix) + "*" + formatString(q.Suffix)
+	}
+	return "*"
+}
+
+// Dump returns an indented tree of the nodes for debugging e.g. in support tickets, unlike Format
+// the result is not a query but shows how the query was parsed
+//
+//	BooleanExpression(AND)
+//	├─ TermQuery(status=open)
+//	└─ RangeQuery(age=[18 TO *])
+func Dump(node interface{}) string {
+	var lines []string
+	if nodes, ok := node.([]interface{}); ok {
+		for _, n := range nodes {
+			lines = dump(n, "", "", lines)
+		}
+	} else {
+		lines = dump(node, "", "", lines)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// dump appends the lines of the node and its arguments, the first line is prefixed with the
+// branch of the node and the lines of its arguments with the indent
+func dump(node interface{}, branch, indent string, lines []string) []string {
+	node = dereference(node)
+	var label string
+	var args []interface{}
+	switch v := node.(type) {
+	case BooleanExpression:
+		label, args = fmt.Sprintf("BooleanExpression(%s)", v.Op), v.Args
+	case TermQuery:
+		op := "="
+		switch {
+		case v.Op == "eq":
+		case v.Op == "in":
+			op = " in "
+		case equalitySymbols[v.Op] != "":
+			op = equalitySymbols[v.Op]
+		case v.Op != "":
+			op = " " + v.Op + " "
+		}
+		label = fmt.Sprintf("TermQuery(%s%s%s%s%s)", v.Prefix, v.Term, formatCast(v.Cast), op, formatValue(v.Value))
+	case RangeQuery:
+		label = fmt.Sprintf("RangeQuery(%s%s=%s)", v.Term, formatCast(v.Cast), RangeQuery{Min: v.Min, Max: v.Max, Inclusive: v.Inclusive})
+	default:
+		label = fmt.Sprintf("%T(%v)", v, v)
+	}
+	lines = append(lines, branch+label)
+	for i, arg := range args {
+		if i == len(args)-1 {
+			lines = dump(arg, indent+"└─ ", indent+"   ", lines)
+			continue
+		}
+		lines = dump(arg, indent+"├─ ", indent+"│  ", lines)
+	}
+	return lines
+}
+
+// Equal returns true when both nodes are structurally equal, pointers to nodes are equal to the nodes
+func Equal(a, b interface{}) bool {
+	return reflect.DeepEqual(dereference(a), dereference(b))
+}
+
+// dereference returns the node with the pointers to nodes replaced by the nodes
+func dereference(node interface{}) interface{} {
+	switch v := node.(type) {
+	case *BooleanExpression:
+		if v == nil {
+			return nil
+		}
+		return dereference(*v)
+	case BooleanExpression:
+		if v.Args != nil {
+			v.Args = dereference(v.Args).([]interface{})
+		}
+		return v
+	case *TermQuery:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case *RangeQuery:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case []interface{}:
+		nodes := make([]interface{}, len(v))
+		for i, n := range v {
+			nodes[i] = dereference(n)
+		}
+		return nodes
+	}
+	return node
+}
+
+// formatArg returns the argument of a boolean expression, nested expressions are parenthesized
+func formatArg(arg interface{}) string {
+	switch v := arg.(type) {
+	case *BooleanExpression:
+		return formatArg(*v)
+	case BooleanExpression:
+		if v.Op == "NOT" && len(v.Args) == 1 {
+			return v.String()
+		}
+		return "(" + v.String() + ")"
+	}
+	return Format(arg)
+}
+
+// formatValue returns the value of a term or range, floats always have a decimal point or exponent
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return formatString(v)
+	case TimeAnchor:
+		return string(v)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eIN") {
+			s += ".0"
+		}
+		return s
+	case bool:
+		return strconv.FormatBool(v)
+	case WildCardQuery:
+		return v.String()
+	case []interface{}:
+		var values []string
+		for _, e := range v {
+			// unquoted array values would include the comma separating them
+			if s, ok := e.(string); ok {
+				values = append(values, quote(s))
+				continue
+			}
+			values = append(values, formatValue(e))
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	}
+	return quote(fmt.Sprintf("%v", value))
+}
+
+// formatBound returns the bound of a range, `*` is an unbounded side
+func formatBound(value interface{}) string {
+	if value == "*" {
+		return "*"
+	}
+	return formatValue(value)
+}
+
+// formatField returns the field name, quoted unless it only has term characters
+func formatField(field string) string {
+	if strings.HasPrefix(field, "/") || field == "_all" || !termChars(field) {
+		return quote(field)
+	}
+	return field
+}
+
+// formatCast returns the `::type` suffix of a field cast to the type
+func formatCast(cast string) string {
+	if cast == "" {
+		return ""
+	}
+	return "::" + cast
+}
+
+// formatString returns the string as an unquoted term unless it would be parsed as
+// something else e.g. a number, keyword or operator
+func formatString(s string) string {
+	if !termChars(s) || strings.ContainsAny(s[:1], "0123456789./<>=!?&|") {
+		return quote(s)
+	}
+	lower := strings.ToLower(s)
+	if reservedWords[lower] || strings.HasPrefix(s, "null") {
+		return quote(s)
+	}
+	return s
+}
+
+// termChars returns true when the string is not empty and only has the characters of unquoted terms
+func termChars(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || strings.ContainsRune(`:)({}"^~\[]*+-`, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// quote returns the string as a quoted term using the escape sequences supported by the grammar
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	escaped := false
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '/':
+			// the grammar unescapes `\/` before the string, which would consume an escaped backslash
+			if escaped {
+				b.WriteString(`\/`)
+			} else {
+				b.WriteRune(r)
+			}
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+		escaped = r == '\\'
+	}
+	b.WriteByte('"')
+	return b.String()
+}
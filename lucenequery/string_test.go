@@ -0,0 +1,112 @@
+package lucenequery
+
+import (
+	"strings"
+	"testing"
+)
+
+// assertRoundTrip asserts the query formatted by String is parsed to a node equal to the parsed query.
+// It is run by executeTestCases for every query of the parser tests
+func assertRoundTrip(t *testing.T, q string, node interface{}, opts ...Option) {
+	t.Helper()
+	formatted := Format(node)
+	got, err := Parse("TestRoundTrip", []byte(formatted), opts...)
+	if err != nil {
+		t.Fatalf("Expected to parse `%s` formatted from `%s` without error, got: %v", formatted, q, err)
+	}
+	if !Equal(node, got) {
+		t.Fatalf("Expected `%s` formatted from `%s` to parse to\n\t%s\n\t\t got \n\t%s", formatted, q, toJSON(t, node), toJSON(t, got))
+	}
+}
+
+func TestString(t *testing.T) {
+	cases := []struct {
+		node     interface{}
+		expected string
+	}{
+		{TermQuery{Term: "name", Value: "peter"}, `name: peter`},
+		{TermQuery{Term: "name", Value: "peter pan"}, `name: "peter pan"`},
+		{TermQuery{Term: "path", Value: `C:\temp\/x`}, `path: "C:\\temp\\\/x"`},
+		{TermQuery{Term: "quote", Value: "a \"park\"\n"}, `quote: "a \"park\"\n"`},
+		{TermQuery{Term: "name", Value: "AND"}, `name: "AND"`},
+		{TermQuery{Term: "name", Value: "nullable"}, `name: "nullable"`},
+		{TermQuery{Term: "name", Value: "contains"}, `name: "contains"`},
+		{TermQuery{Term: "period", Value: "2020-06-01", Op: "contains"}, `period: contains "2020-06-01"`},
+		{TermQuery{Term: "zip", Value: "02134"}, `zip: "02134"`},
+		{TermQuery{Term: "age", Value: 5.0}, `age: 5.0`},
+		{TermQuery{Term: "age", Value: 5, Op: "neq", Prefix: "-"}, `-age: != 5`},
+		{TermQuery{Value: 5, Prefix: "-"}, `NOT 5`},
+		{TermQuery{Value: "foo", Prefix: "-"}, `-foo`},
+		{TermQuery{Term: "tags", Value: []interface{}{"a", "b c", 1}, Op: "in"}, `tags: ["a", "b c", 1]`},
+		{TermQuery{Term: "name", Value: WildCardQuery{Prefix: "pet", Suffix: "er man"}}, `name: pet*"er man"`},
+		{TermQuery{Term: "first name", Value: nil}, `"first name": null`},
+		{RangeQuery{Term: "age", Min: 18, Max: "*", Inclusive: true}, `age: [18 TO *]`},
+		{RangeQuery{Term: "name", Min: "TO", Max: "z", Inclusive: false}, `name: {"TO" TO z}`},
+		{TermQuery{Term: "first id", Value: 5, Op: "neq", Cast: "int"}, `"first id"::int: != 5`},
+		{RangeQuery{Term: "created", Min: "2020-01-01", Max: "*", Inclusive: true, Cast: "date"}, `created::date: ["2020-01-01" TO *]`},
+		{
+			BooleanExpression{Op: "AND", Args: []interface{}{
+				TermQuery{Term: "a", Value: 1},
+				BooleanExpression{Op: "OR", Args: []interface{}{TermQuery{Term: "b", Value: 2}, TermQuery{Term: "c", Value: 3}}},
+			}},
+			`a: 1 AND (b: 2 OR c: 3)`,
+		},
+		{
+			BooleanExpression{Op: "NOT", Args: []interface{}{
+				BooleanExpression{Op: "IMPLICIT", Args: []interface{}{TermQuery{Value: "a"}, TermQuery{Value: "b"}}},
+			}},
+			`NOT (a b)`,
+		},
+	}
+	for _, tc := range cases {
+		if got := Format(tc.node); got != tc.expected {
+			t.Errorf("Expected %#v to be formatted as `%s`, got: `%s`", tc.node, tc.expected, got)
+		}
+		assertRoundTrip(t, tc.expected, tc.node)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	term := TermQuery{Term: "a", Value: 1}
+	if !Equal(BooleanExpression{Op: "AND", Args: []interface{}{term, &term}}, &BooleanExpression{Op: "AND", Args: []interface{}{&term, term}}) {
+		t.Errorf("Expected pointers to nodes to equal the nodes")
+	}
+	if Equal(term, TermQuery{Term: "a", Value: 1.0}) {
+		t.Errorf("Expected values of different types not to be equal")
+	}
+}
+
+func TestDump(t *testing.T) {
+	q, err := Parse("TestDump", []byte(`status: open AND (age: >= 18 OR -name: "peter pan") AND tags: ["go", "sql"]`))
+	if err != nil {
+		t.Fatalf("Expected to parse without error, got: %v", err)
+	}
+	expected := strings.Join([]string{
+		"BooleanExpression(AND)",
+		"├─ TermQuery(status=open)",
+		"└─ BooleanExpression(AND)",
+		"   ├─ BooleanExpression(OR)",
+		"   │  ├─ RangeQuery(age=[18 TO *])",
+		`   │  └─ TermQuery(-name="peter pan")`,
+		`   └─ TermQuery(tags in ["go", "sql"])`,
+	}, "\n")
+	if got := Dump(q); got != expected {
+		t.Errorf("Expected dump\n%s\ngot\n%s", expected, got)
+	}
+
+	cases := map[string]interface{}{
+		"TermQuery(status=open)":                    &TermQuery{Term: "status", Value: "open"},
+		"TermQuery(age>5)":                          TermQuery{Term: "age", Value: 5, Op: "gt"},
+		"TermQuery(name ?? peter)":                  TermQuery{Term: "name", Value: "peter", Op: "??"},
+		"TermQuery(name=pet*)":                      TermQuery{Term: "name", Value: WildCardQuery{Prefix: "pet"}},
+		"RangeQuery(age={1 TO 5})":                  RangeQuery{Term: "age", Min: 1, Max: 5},
+		"TermQuery(id::int=5)":                      TermQuery{Term: "id", Value: 5, Cast: "int"},
+		"BooleanExpression(NOT)\n└─ TermQuery(a=b)": BooleanExpression{Op: "NOT", Args: []interface{}{TermQuery{Term: "a", Value: "b"}}},
+		"TermQuery(a=b)\nTermQuery(c=d)":            []interface{}{TermQuery{Term: "a", Value: "b"}, TermQuery{Term: "c", Value: "d"}},
+	}
+	for expected, node := range cases {
+		if got := Dump(node); got != expected {
+			t.Errorf("Expected dump\n%s\ngot\n%s", expected, got)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package lucenequery
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// ParseURLEncoded parses a percent-encoded query e.g. the raw value of a URL query parameter
+// `name%3A%20john`. Only percent escapes are decoded, a `+` is kept as the required operator
+// rather than decoded as a space so clients must encode it as `%2B` only when it is part of a value
+func ParseURLEncoded(input string, opts ...Option) (interface{}, error) {
+	query, err := url.PathUnescape(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url encoded query: %w", err)
+	}
+	return Parse("ParseURLEncoded", []byte(query), opts...)
+}
@@ -0,0 +1,37 @@
+package lucenequery
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseURLEncoded(t *testing.T) {
+	cases := map[string]interface{}{
+		`name%3A%20john`:                         TermQuery{Term: "name", Value: "john"},
+		`name%3Ajohn`:                            TermQuery{Term: "name", Value: "john"},
+		`name:john`:                              TermQuery{Term: "name", Value: "john"},
+		`name%3A%22john%20smith%22`:              TermQuery{Term: "name", Value: "john smith"},
+		`url%3A%20https%3A%2F%2Fexample.com%2Fa`: TermQuery{Term: "url", Value: "https://example.com/a"},
+		`%2Bname%3Ajohn%20%2Dage%3A5`: BooleanExpression{
+			Op: "IMPLICIT",
+			Args: []interface{}{
+				TermQuery{Term: "name", Value: "john", Prefix: "+"},
+				TermQuery{Term: "age", Value: 5, Prefix: "-"},
+			},
+		},
+		`+name:john`: TermQuery{Term: "name", Value: "john", Prefix: "+"},
+	}
+	for q, expected := range cases {
+		got, err := ParseURLEncoded(q)
+		if err != nil {
+			t.Fatalf("Expected to parse %s without error, got: %v", q, err)
+		}
+		if !reflect.DeepEqual(expected, got) {
+			t.Errorf("Expected lucenequery `%s` to equal %#v, got %#v", q, expected, got)
+		}
+	}
+
+	if _, err := ParseURLEncoded(`name%3Ajohn%ZZ`); err == nil {
+		t.Errorf("Expected an invalid escape to fail")
+	}
+}
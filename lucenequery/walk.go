@@ -0,0 +1,141 @@
+package lucenequery
+
+import (
+	"fmt"
+)
+
+// Term is a field and value referenced by a query
+type Term struct {
+	Field string
+	Value string
+}
+
+// Walk traverses the nodes of a parsed query depth first, calling fn for every node.
+// The children of a node are not visited when fn returns false
+func Walk(node interface{}, fn func(node interface{}) bool) {
+	switch v := node.(type) {
+	case []interface{}:
+		for _, n := range v {
+			Walk(n, fn)
+		}
+	case BooleanExpression:
+		if fn(v) {
+			Walk(v.Args, fn)
+		}
+	case *BooleanExpression:
+		Walk(*v, fn)
+	case *TermQuery:
+		Walk(*v, fn)
+	case *RangeQuery:
+		Walk(*v, fn)
+	default:
+		fn(v)
+	}
+}
+
+// Flatten returns the node with the boolean expressions of a single argument replaced by their argument
+// e.g. the `((status: open))` of a tree built in code is the term `status: open`. A NOT expression is
+// kept as it negates its argument
+func Flatten(node interface{}) interface{} {
+	switch v := node.(type) {
+	case *BooleanExpression:
+		if v == nil {
+			return node
+		}
+		return Flatten(*v)
+	case BooleanExpression:
+		args := make([]interface{}, len(v.Args))
+		for i, arg := range v.Args {
+			args[i] = Flatten(arg)
+		}
+		if len(args) == 1 && v.Op != "NOT" {
+			return args[0]
+		}
+		v.Args = args
+		return v
+	case []interface{}:
+		nodes := make([]interface{}, len(v))
+		for i, n := range v {
+			nodes[i] = Flatten(n)
+		}
+		return nodes
+	}
+	return node
+}
+
+// Terms returns the field and values matched by the term queries of the node e.g. for highlighting
+// the matches in search results. Ranges, null values and negated terms are excluded
+func Terms(node interface{}) []Term {
+	terms := []Term{}
+	Walk(node, func(n interface{}) bool {
+		switch v := n.(type) {
+		case BooleanExpression:
+			if v.Op == "NOT" && len(v.Args) == 1 {
+				return false
+			}
+			if v.Op == "NOT" && len(v.Args) > 0 {
+				terms = append(terms, Terms(v.Args[0])...)
+				return false
+			}
+		case TermQuery:
+			if v.Prefix == "-" {
+				return false
+			}
+			terms = append(terms, termValues(v.Term, v.Value)...)
+		}
+		return true
+	})
+	return terms
+}
+
+func termValues(field string, value interface{}) []Term {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		var terms []Term
+		for _, e := range v {
+			terms = append(terms, termValues(field, e)...)
+		}
+		return terms
+	case WildCardQuery:
+		if v.Term != "" {
+			return []Term{{Field: field, Value: fmt.Sprintf("*%s*", v.Term)}}
+		}
+		return []Term{{Field: field, Value: fmt.Sprintf("%s*%s", v.Prefix, v.Suffix)}}
+	default:
+		return []Term{{Field: field, Value: fmt.Sprintf("%v", v)}}
+	}
+}
+
+// ParseClauses parses the query returning its top-level clauses e.g. to display each clause of a
+// search separately. The clauses are the arguments of the root expression, flattened across the
+// nested expressions of the same operator so `a b c` returns the three terms. A query with a single
+// clause, or a negated root, returns a single clause
+func ParseClauses(input string, opts ...Option) ([]interface{}, error) {
+	node, err := Parse("ParseClauses", []byte(input), opts...)
+	if err != nil {
+		return nil, err
+	}
+	if nodes, ok := node.([]interface{}); ok {
+		return nodes, nil
+	}
+	root, ok := node.(BooleanExpression)
+	if !ok || root.Op == "NOT" || len(root.Args) == 0 {
+		return []interface{}{node}, nil
+	}
+	return clauses(root.Args, root.Op), nil
+}
+
+// clauses returns the nodes, flattening the expressions with the operator into their arguments
+func clauses(nodes []interface{}, op string) []interface{} {
+	var result []interface{}
+	for _, n := range nodes {
+		if v, ok := n.(BooleanExpression); ok && v.Op == op {
+			result = append(result, clauses(v.Args, op)...)
+			continue
+		}
+		result = append(result, n)
+	}
+	return result
+}
@@ -0,0 +1,113 @@
+package lucenequery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalk(t *testing.T) {
+	node, err := Parse("TestWalk", []byte(`(title: go OR age: [18 TO 25]) AND tags: [a,b]`))
+	assert.NoError(t, err)
+	var nodes []string
+	Walk(node, func(n interface{}) bool {
+		switch v := n.(type) {
+		case BooleanExpression:
+			nodes = append(nodes, v.Op)
+		case TermQuery:
+			nodes = append(nodes, v.Term)
+		case RangeQuery:
+			nodes = append(nodes, v.Term)
+		}
+		return true
+	})
+	assert.Equal(t, []string{"AND", "OR", "title", "age", "tags"}, nodes)
+
+	nodes = nil
+	Walk(node, func(n interface{}) bool {
+		if v, ok := n.(BooleanExpression); ok {
+			nodes = append(nodes, v.Op)
+		}
+		return false
+	})
+	assert.Equal(t, []string{"AND"}, nodes)
+}
+
+func TestTerms(t *testing.T) {
+	cases := map[string][]Term{
+		`title: "pink panther"`: {{Field: "title", Value: "pink panther"}},
+		`((title: go OR body: gopher*) AND age: [18 TO 25]) status: ["open", 2] status: -closed`: {
+			{Field: "title", Value: "go"},
+			{Field: "body", Value: "gopher*"},
+			{Field: "status", Value: "open"},
+			{Field: "status", Value: "2"},
+		},
+		`"jakarta apache" NOT "Apache Lucene"`:      {{Field: "", Value: "jakarta apache"}},
+		`deleted: null age: > 18`:                   {},
+		`title: go NOT (body: rust OR body: c)`:     {{Field: "title", Value: "go"}},
+		`title: go AND NOT (body: rust OR body: c)`: {{Field: "title", Value: "go"}},
+	}
+	for q, expected := range cases {
+		node, err := Parse("TestTerms", []byte(q))
+		assert.NoError(t, err, q)
+		assert.Equal(t, expected, Terms(node), q)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	status := TermQuery{Term: "status", Value: "open"}
+	name := TermQuery{Term: "name", Value: "peter"}
+	cases := []struct {
+		node     interface{}
+		expected interface{}
+	}{
+		{BooleanExpression{Op: "AND", Args: []interface{}{BooleanExpression{Op: "OR", Args: []interface{}{status}}}}, status},
+		{&BooleanExpression{Op: "AND", Args: []interface{}{&BooleanExpression{Op: "OR", Args: []interface{}{status}}}}, status},
+		{
+			BooleanExpression{Op: "AND", Args: []interface{}{BooleanExpression{Op: "OR", Args: []interface{}{status}}, name}},
+			BooleanExpression{Op: "AND", Args: []interface{}{status, name}},
+		},
+		{
+			BooleanExpression{Op: "NOT", Args: []interface{}{BooleanExpression{Op: "AND", Args: []interface{}{status}}}},
+			BooleanExpression{Op: "NOT", Args: []interface{}{status}},
+		},
+		{[]interface{}{BooleanExpression{Op: "AND", Args: []interface{}{status}}, name}, []interface{}{status, name}},
+		{status, status},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, Flatten(tc.node), Format(tc.node))
+	}
+}
+
+func TestParseClauses(t *testing.T) {
+	cases := []struct {
+		query    string
+		expected []interface{}
+	}{
+		{`a b c`, []interface{}{TermQuery{Value: "a"}, TermQuery{Value: "b"}, TermQuery{Value: "c"}}},
+		{`a AND b AND c`, []interface{}{TermQuery{Value: "a"}, TermQuery{Value: "b"}, TermQuery{Value: "c"}}},
+		{`status: open age: [18 TO 25] (a OR b)`, []interface{}{
+			TermQuery{Term: "status", Value: "open"},
+			RangeQuery{Term: "age", Min: 18, Max: 25, Inclusive: true},
+			BooleanExpression{Op: "OR", Args: []interface{}{TermQuery{Value: "a"}, TermQuery{Value: "b"}}},
+		}},
+		{`a AND b c`, []interface{}{
+			TermQuery{Value: "a"},
+			BooleanExpression{Op: "IMPLICIT", Args: []interface{}{TermQuery{Value: "b"}, TermQuery{Value: "c"}}},
+		}},
+		{`name: peter`, []interface{}{TermQuery{Term: "name", Value: "peter"}}},
+		{`NOT (a OR b)`, []interface{}{
+			BooleanExpression{Op: "NOT", Args: []interface{}{
+				BooleanExpression{Op: "OR", Args: []interface{}{TermQuery{Value: "a"}, TermQuery{Value: "b"}}},
+			}},
+		}},
+	}
+	for _, tc := range cases {
+		got, err := ParseClauses(tc.query)
+		assert.NoError(t, err, tc.query)
+		assert.Equal(t, tc.expected, got, tc.query)
+	}
+
+	_, err := ParseClauses(``)
+	assert.Error(t, err)
+}